@@ -0,0 +1,405 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode controls how a Store answers lookups
+type Mode int
+
+const (
+	// ModeNormal serves fresh entries and lets callers fetch and store misses
+	ModeNormal Mode = iota
+	// ModeOffline serves any stored entry regardless of its age and never expects a fetch
+	ModeOffline
+	// ModeRefresh ignores stored entries so every lookup is refetched and overwritten
+	ModeRefresh
+)
+
+// ErrMiss is returned when no usable entry exists for a lookup
+var ErrMiss = errors.New("cache miss")
+
+// DefaultTTLs holds the freshness window of each GraphQL operation
+var DefaultTTLs = map[string]time.Duration{
+	"questionData":           21 * 24 * time.Hour,
+	"problemsetQuestionList": 24 * time.Hour,
+	"favoriteQuestionList":   6 * time.Hour,
+	// the problems of a past contest never change
+	"contestQuestionList": 30 * 24 * time.Hour,
+	"pastContests":        24 * time.Hour,
+	"companyTags":         7 * 24 * time.Hour,
+	// solved counts change with every accepted submission
+	"userPublicProfile": 15 * time.Minute,
+}
+
+// UserOperations answer differently depending on the signed in user, e.g. with the
+// status of each question; their entries are kept apart per user, see SetUser
+var UserOperations = map[string]bool{
+	"favoriteQuestionList":   true,
+	"problemsetQuestionList": true,
+	"questionOfToday":        true,
+	"userStatus":             true,
+	"myCreatedFavoriteList":  true,
+}
+
+// DefaultTTL is used for operations missing from the TTL table
+const DefaultTTL = time.Hour
+
+// Entry is a single cached response as stored on disk
+type Entry struct {
+	Key       string          `json:"key"`
+	Operation string          `json:"operation"`
+	Variables json.RawMessage `json:"variables"`
+	StoredAt  time.Time       `json:"storedAt"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// EntryInfo describes a cached entry without its body
+type EntryInfo struct {
+	Key       string
+	Operation string
+	StoredAt  time.Time
+	Size      int64
+	Expired   bool
+	// Variables are the normalized variables of the request, nil for unreadable entries
+	Variables json.RawMessage
+	path      string
+}
+
+// Store is a content-addressed on-disk cache of GraphQL responses
+type Store struct {
+	dir  string
+	mode Mode
+	ttls map[string]time.Duration
+	user string
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewStore creates a cache rooted at dir using the default TTLs
+func NewStore(dir string) *Store {
+	ttls := make(map[string]time.Duration, len(DefaultTTLs))
+	for op, ttl := range DefaultTTLs {
+		ttls[op] = ttl
+	}
+	return &Store{dir: dir, ttls: ttls, now: time.Now}
+}
+
+// Dir returns the directory the cache lives in
+func (c *Store) Dir() string {
+	return c.dir
+}
+
+// Mode returns the lookup mode of the cache
+func (c *Store) Mode() Mode {
+	return c.mode
+}
+
+// SetMode changes the lookup mode of the cache
+func (c *Store) SetMode(mode Mode) {
+	c.mode = mode
+}
+
+// SetUser keeps entries of UserOperations apart for the given user, e.g. the session
+// cookie, so profiles sharing a cache never read each other's answers. Only a hash of
+// user ends up in the keys; an empty user shares the entries of anonymous requests.
+func (c *Store) SetUser(user string) {
+	c.user = user
+}
+
+// SetTTL overrides the freshness window of an operation
+func (c *Store) SetTTL(operation string, ttl time.Duration) {
+	c.ttls[operation] = ttl
+}
+
+// TTL returns the freshness window of an operation
+func (c *Store) TTL(operation string) time.Duration {
+	if ttl, ok := c.ttls[operation]; ok {
+		return ttl
+	}
+	return DefaultTTL
+}
+
+// Key derives the cache key of an operation from its name, its query and normalized
+// variables; a query asking for other fields never reads entries of the old one.
+// Whitespace in the query does not change the key.
+func Key(operation, query string, variables interface{}) (string, error) {
+	normalized, err := normalize(variables)
+	if err != nil {
+		return "", err
+	}
+	data := operation + "\n" + strings.Join(strings.Fields(query), " ") + "\n"
+	sum := sha256.Sum256(append([]byte(data), normalized...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// key derives the key of an entry, scoped to the user for UserOperations
+func (c *Store) key(operation, query string, variables interface{}) (string, error) {
+	key, err := Key(operation, query, variables)
+	if err != nil || c.user == "" || !UserOperations[operation] {
+		return key, err
+	}
+	sum := sha256.Sum256([]byte(c.user + "\n" + key))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalize re-encodes variables through a generic value so that structs and maps
+// holding the same data produce identical bytes with sorted keys
+func normalize(variables interface{}) ([]byte, error) {
+	raw, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to normalize variables: %w", err)
+	}
+	return json.Marshal(generic)
+}
+
+// Get returns the cached body for an operation or ErrMiss
+func (c *Store) Get(operation, query string, variables interface{}) ([]byte, error) {
+	if c.mode == ModeRefresh {
+		return nil, ErrMiss
+	}
+
+	key, err := c.key(operation, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.path(operation, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrMiss
+		}
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, ErrMiss
+	}
+	if c.mode != ModeOffline && c.now().Sub(entry.StoredAt) > c.TTL(operation) {
+		return nil, ErrMiss
+	}
+
+	return entry.Body, nil
+}
+
+// Put stores the body returned for an operation
+func (c *Store) Put(operation, query string, variables interface{}, body []byte) error {
+	if !json.Valid(body) {
+		return fmt.Errorf("refusing to cache non-JSON response for %s", operation)
+	}
+
+	key, err := c.key(operation, query, variables)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalize(variables)
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{
+		Key:       key,
+		Operation: operation,
+		Variables: normalized,
+		StoredAt:  c.now(),
+		Body:      body,
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	filename := c.path(operation, key)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns every stored entry, optionally restricted to one operation,
+// ordered from oldest to newest
+func (c *Store) List(operation string) ([]EntryInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var infos []EntryInfo
+	err := filepath.Walk(c.dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		op := filepath.Base(filepath.Dir(path))
+		if operation != "" && op != operation {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			// Unreadable entries are reported with a zero time so they are pruned first
+			entry = Entry{Key: strings.TrimSuffix(fi.Name(), ".json"), Operation: op}
+		}
+
+		infos = append(infos, EntryInfo{
+			Key:       entry.Key,
+			Operation: op,
+			StoredAt:  entry.StoredAt,
+			Size:      fi.Size(),
+			Expired:   c.now().Sub(entry.StoredAt) > c.TTL(op),
+			Variables: entry.Variables,
+			path:      path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].StoredAt.Before(infos[j].StoredAt)
+	})
+	return infos, nil
+}
+
+// Size returns the number of entries and the bytes they occupy on disk
+func (c *Store) Size() (int, int64, error) {
+	infos, err := c.List("")
+	if err != nil {
+		return 0, 0, err
+	}
+	var total int64
+	for _, info := range infos {
+		total += info.Size
+	}
+	return len(infos), total, nil
+}
+
+// Invalidate removes entries of an operation (all operations when empty) that are
+// older than olderThan; a zero olderThan removes them regardless of age
+func (c *Store) Invalidate(operation string, olderThan time.Duration) (int, error) {
+	infos, err := c.List(operation)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, info := range infos {
+		if olderThan > 0 && c.now().Sub(info.StoredAt) <= olderThan {
+			continue
+		}
+		if err := c.remove(info); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// InvalidateWhere removes entries of an operation whose variables satisfy match,
+// e.g. the cached pages of one favorite list
+func (c *Store) InvalidateWhere(operation string, match func(variables map[string]interface{}) bool) (int, error) {
+	infos, err := c.List(operation)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, info := range infos {
+		var variables map[string]interface{}
+		if err := json.Unmarshal(info.Variables, &variables); err != nil || !match(variables) {
+			continue
+		}
+		if err := c.remove(info); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Prune evicts expired entries and then the oldest ones until the cache fits in maxBytes
+func (c *Store) Prune(maxBytes int64) (int, error) {
+	infos, err := c.List("")
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, info := range infos {
+		total += info.Size
+	}
+
+	removed := 0
+	var kept []EntryInfo
+	for _, info := range infos {
+		if !info.Expired {
+			kept = append(kept, info)
+			continue
+		}
+		if err := c.remove(info); err != nil {
+			return removed, err
+		}
+		total -= info.Size
+		removed++
+	}
+
+	for _, info := range kept {
+		if maxBytes <= 0 || total <= maxBytes {
+			break
+		}
+		if err := c.remove(info); err != nil {
+			return removed, err
+		}
+		total -= info.Size
+		removed++
+	}
+	return removed, nil
+}
+
+func (c *Store) remove(info EntryInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Remove(info.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache entry %s: %w", info.Key, err)
+	}
+	return nil
+}
+
+func (c *Store) path(operation, key string) string {
+	return filepath.Join(c.dir, operation, key+".json")
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const query = `
+	query questionData($titleSlug: String!) {
+		question(titleSlug: $titleSlug) { title }
+	}`
+
+func TestKeyNormalization(t *testing.T) {
+	type variables struct {
+		Limit int    `json:"limit"`
+		Slug  string `json:"slug"`
+	}
+	fromStruct, err := Key("op", query, variables{Limit: 5, Slug: "two-sum"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromMap, err := Key("op", query, map[string]interface{}{"slug": "two-sum", "limit": 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromStruct != fromMap {
+		t.Error("structs and maps holding the same variables must share a key")
+	}
+
+	reindented, _ := Key("op", "query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { title } }", variables{Limit: 5, Slug: "two-sum"})
+	if reindented != fromStruct {
+		t.Error("whitespace in the query must not change the key")
+	}
+
+	moreFields, _ := Key("op", "query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { title content } }", variables{Limit: 5, Slug: "two-sum"})
+	otherOperation, _ := Key("other", query, variables{Limit: 5, Slug: "two-sum"})
+	otherVariables, _ := Key("op", query, variables{Limit: 6, Slug: "two-sum"})
+	for name, key := range map[string]string{"query": moreFields, "operation": otherOperation, "variables": otherVariables} {
+		if key == fromStruct {
+			t.Errorf("a different %s must change the key", name)
+		}
+	}
+}
+
+func TestTTLExpiry(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	store.SetTTL("op", time.Hour)
+
+	variables := map[string]interface{}{"slug": "two-sum"}
+	if err := store.Put("op", query, variables, []byte(`{"data":1}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(59 * time.Minute)
+	if body, err := store.Get("op", query, variables); err != nil || string(body) != `{"data":1}` {
+		t.Fatalf("a fresh entry must be served, got %s, %v", body, err)
+	}
+	if _, err := store.Get("op", query+" ", map[string]interface{}{"slug": "lru-cache"}); !errors.Is(err, ErrMiss) {
+		t.Errorf("other variables must miss, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := store.Get("op", query, variables); !errors.Is(err, ErrMiss) {
+		t.Errorf("an expired entry must miss, got %v", err)
+	}
+	infos, err := store.List("")
+	if err != nil || len(infos) != 1 || !infos[0].Expired {
+		t.Errorf("expired entries are listed as expired, got %+v, %v", infos, err)
+	}
+	if removed, err := store.Prune(0); err != nil || removed != 1 {
+		t.Errorf("prune must remove the expired entry, removed %d, %v", removed, err)
+	}
+}
+
+func TestModes(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	variables := map[string]interface{}{"slug": "two-sum"}
+	if err := store.Put("op", query, variables, []byte(`{"data":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * 24 * time.Hour)
+
+	store.SetMode(ModeOffline)
+	if body, err := store.Get("op", query, variables); err != nil || string(body) != `{"data":1}` {
+		t.Errorf("offline mode must serve expired entries, got %s, %v", body, err)
+	}
+	if _, err := store.Get("op", query, map[string]interface{}{"slug": "lru-cache"}); !errors.Is(err, ErrMiss) {
+		t.Errorf("offline mode must still miss unknown entries, got %v", err)
+	}
+
+	now = now.Add(-30 * 24 * time.Hour)
+	store.SetMode(ModeRefresh)
+	if _, err := store.Get("op", query, variables); !errors.Is(err, ErrMiss) {
+		t.Errorf("refresh mode must ignore stored entries, got %v", err)
+	}
+}
+
+func TestPutRejectsInvalidJSON(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Put("op", query, nil, []byte("<html>rate limited</html>")); err == nil {
+		t.Error("non-JSON bodies must not be cached")
+	}
+}
+
+func TestUserOperationsAreKeptApart(t *testing.T) {
+	dir := t.TempDir()
+	alice, bob := NewStore(dir), NewStore(dir)
+	alice.SetUser("alice-session")
+	bob.SetUser("bob-session")
+
+	variables := map[string]interface{}{"favoriteSlug": "amazon-thirty-days"}
+	if err := alice.Put("favoriteQuestionList", query, variables, []byte(`{"status":"SOLVED"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bob.Get("favoriteQuestionList", query, variables); !errors.Is(err, ErrMiss) {
+		t.Errorf("another user must not read the list with alice's statuses, got %v", err)
+	}
+	if _, err := NewStore(dir).Get("favoriteQuestionList", query, variables); !errors.Is(err, ErrMiss) {
+		t.Errorf("anonymous requests must not read alice's list, got %v", err)
+	}
+
+	details := map[string]interface{}{"titleSlug": "two-sum"}
+	if err := alice.Put("questionData", query, details, []byte(`{"title":"Two Sum"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bob.Get("questionData", query, details); err != nil {
+		t.Errorf("answers that do not depend on the user are shared, got %v", err)
+	}
+
+	if infos, err := alice.List("favoriteQuestionList"); err != nil || len(infos) != 1 {
+		t.Errorf("entries of every user are listed, got %d, %v", len(infos), err)
+	}
+}
+
+func TestInvalidateWhere(t *testing.T) {
+	store := NewStore(t.TempDir())
+	for _, slug := range []string{"study", "amazon-thirty-days"} {
+		for skip := 0; skip < 20; skip += 10 {
+			variables := map[string]interface{}{"favoriteSlug": slug, "skip": skip}
+			if err := store.Put("favoriteQuestionList", query, variables, []byte(`{}`)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	removed, err := store.InvalidateWhere("favoriteQuestionList", func(variables map[string]interface{}) bool {
+		return variables["favoriteSlug"] == "study"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if infos, _ := store.List(""); removed != 2 || len(infos) != 2 {
+		t.Errorf("removed %d entries and kept %d, want both pages of study gone only", removed, len(infos))
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func runCache(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, expected one of stats, list, invalidate, prune")
+	}
+
+	fs := flag.NewFlagSet("cache "+args[0], flag.ExitOnError)
+	var sf scraperFlags
+	sf.register(fs)
+	operation := fs.String("op", "", "restrict to one GraphQL operation, e.g. questionData")
+	olderThan := fs.Duration("older-than", 0, "only invalidate entries older than this, e.g. 72h")
+	maxSize := fs.String("max-size", "", "size limit to prune the cache to, e.g. 200MB")
+	if err := sf.parse(fs, args[1:]); err != nil {
+		return err
+	}
+
+	store := sf.cacheStore()
+
+	switch args[0] {
+	case "stats":
+		count, size, err := store.Size()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d entries, %s\n", store.Dir(), count, formatSize(size))
+
+	case "list":
+		infos, err := store.List(*operation)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			state := "fresh"
+			if info.Expired {
+				state = "expired"
+			}
+			fmt.Printf("%-24s %s %s %8s %s\n", info.Operation, shortKey(info.Key), info.StoredAt.Format(time.RFC3339), formatSize(info.Size), state)
+		}
+		fmt.Printf("%d entries\n", len(infos))
+
+	case "invalidate":
+		removed, err := store.Invalidate(*operation, *olderThan)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d entries\n", removed)
+
+	case "prune":
+		limit, err := parseSize(*maxSize)
+		if err != nil {
+			return err
+		}
+		removed, err := store.Prune(limit)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d entries\n", removed)
+
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+// shortKey abbreviates a cache key for display; keys of unreadable entries come from
+// their filename and may be shorter
+func shortKey(key string) string {
+	if len(key) > 12 {
+		return key[:12]
+	}
+	return key
+}
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// parseSize parses sizes such as 512KB or 1GB; an empty string means no limit
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			multiplier = unit.bytes
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+func formatSize(n int64) string {
+	for _, unit := range sizeUnits {
+		if n >= unit.bytes && unit.bytes > 1 {
+			return fmt.Sprintf("%.1f%s", float64(n)/float64(unit.bytes), unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -1,45 +1,226 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
-	"log"
-	"sync"
 )
 
-type Headers struct {
-	Cookie string `yaml:"cookie" json:"-"`
+// EnvPrefix prefixes the environment variables overriding config keys: the key
+// path is upper cased with dots turned into underscores, e.g. app.secrets.file is
+// overridden by LEETCODE_SCRAPPER_APP_SECRETS_FILE
+const EnvPrefix = "LEETCODE_SCRAPPER"
+
+// Formats lists the config file extensions Load understands
+var Formats = []string{"yaml", "yml", "toml", "json"}
+
+// Secrets tells where credentials are kept; the config never holds them itself
+type Secrets struct {
+	File string `mapstructure:"file"`
 }
 
-func readConfig(filename string) {
-	viper.SetConfigFile(filename)
-	err := viper.ReadInConfig()
-	if err != nil {
-		log.Fatalf("unable to read config file")
-	}
+// Scraper configures requests to LeetCode
+type Scraper struct {
+	BaseURL string        `mapstructure:"base_url"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Rate is the minimum delay between two requests
+	Rate     time.Duration `mapstructure:"rate"`
+	Retries  int           `mapstructure:"retries"`
+	Backoff  time.Duration `mapstructure:"backoff"`
+	PageSize int           `mapstructure:"page_size"`
+}
+
+// Storage locates the files the tool reads and writes
+type Storage struct {
+	DataDir  string `mapstructure:"data_dir"`
+	CacheDir string `mapstructure:"cache_dir"`
+	Catalog  string `mapstructure:"catalog"`
+	Solved   string `mapstructure:"solved"`
+	// Daily is the log of completed daily challenges
+	Daily string `mapstructure:"daily"`
+	// Companies is the company catalog written by the discover command
+	Companies   string `mapstructure:"companies"`
+	SnapshotDir string `mapstructure:"snapshot_dir"`
+	// SnapshotRetention is how many snapshots of each list and profile are kept, 0 disables them
+	SnapshotRetention int `mapstructure:"snapshot_retention"`
+}
+
+// Picker holds the defaults of the pick command
+type Picker struct {
+	List           string   `mapstructure:"list"`
+	Count          int      `mapstructure:"count"`
+	Difficulty     []string `mapstructure:"difficulty"`
+	ExcludePremium bool     `mapstructure:"exclude_premium"`
+}
+
+// Export holds the defaults of the export and report commands
+type Export struct {
+	Format  string   `mapstructure:"format"`
+	Columns []string `mapstructure:"columns"`
+	GroupBy string   `mapstructure:"group_by"`
+	Deck    string   `mapstructure:"deck"`
+	SiteDir string   `mapstructure:"site_dir"`
 }
 
 type Application struct {
-	Headers Headers `yaml:"headers" json:"-"`
+	// DefaultProfile is applied when no profile is asked for
+	DefaultProfile string             `mapstructure:"default_profile"`
+	Profiles       map[string]Profile `mapstructure:"profiles"`
+
+	Secrets Secrets `mapstructure:"secrets"`
+	Scraper Scraper `mapstructure:"scraper"`
+	Storage Storage `mapstructure:"storage"`
+	Picker  Picker  `mapstructure:"picker"`
+	Export  Export  `mapstructure:"export"`
+
+	// file is the config file, checked for committed credentials
+	file string
+	// profile is the profile applied by WithProfile, base the config it was applied to
+	profile string
+	base    *Application
+}
+
+// File returns the file the config was loaded from, empty for defaults
+func (a *Application) File() string {
+	return a.file
+}
+
+// defaults are the values of keys missing from the config file. Every key must have
+// one, viper only applies environment overrides to keys it knows about.
+var defaults = map[string]interface{}{
+	"app.default_profile": "",
+
+	"app.secrets.file": DefaultSecretsFile,
+
+	"app.scraper.base_url":  "https://leetcode.com/graphql/",
+	"app.scraper.timeout":   "30s",
+	"app.scraper.rate":      "1s",
+	"app.scraper.retries":   3,
+	"app.scraper.backoff":   "2s",
+	"app.scraper.page_size": 10,
+
+	"app.storage.data_dir":           "updated_data",
+	"app.storage.cache_dir":          ".cache/leetcode",
+	"app.storage.catalog":            "data/catalog.json",
+	"app.storage.solved":             "data/solved.txt",
+	"app.storage.daily":              "data/daily.txt",
+	"app.storage.companies":          "data/companies.json",
+	"app.storage.snapshot_dir":       "data/snapshots",
+	"app.storage.snapshot_retention": 0,
+
+	"app.picker.list":            "amazon-thirty-days",
+	"app.picker.count":           3,
+	"app.picker.difficulty":      []string{"MEDIUM", "HARD"},
+	"app.picker.exclude_premium": true,
+
+	"app.export.format":   "csv",
+	"app.export.columns":  []string{},
+	"app.export.group_by": "topic",
+	"app.export.deck":     "LeetCode",
+	"app.export.site_dir": "site",
 }
 
-func loadApp(filename string) error {
-	readConfig(filename)
-	viper.AutomaticEnv()
+// Load reads a YAML, TOML or JSON config, chosen by the extension of path, applies
+// environment overrides and validates the result. An empty path loads the defaults
+// and the environment only.
+func Load(path string) (*Application, error) {
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		ext := strings.TrimPrefix(filepath.Ext(path), ".")
+		if !contains(Formats, ext) {
+			return nil, fmt.Errorf("unsupported config format %q, expected one of %s", ext, strings.Join(Formats, ", "))
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+
+	var cfg struct {
+		App Application `mapstructure:"app"`
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+	app := &cfg.App
+	app.file = path
+
+	if err := app.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return app, nil
+}
 
-	appConfig = &Application{
-		Headers: Headers{Cookie: viper.GetString("app.secrets.cookie")},
+// Validate reports every invalid or missing field at once
+func (a *Application) Validate() error {
+	var errs []error
+	required := map[string]string{
+		"app.secrets.file":     a.Secrets.File,
+		"app.scraper.base_url": a.Scraper.BaseURL,
+		"app.storage.data_dir": a.Storage.DataDir,
+		"app.storage.solved":   a.Storage.Solved,
+		"app.picker.list":      a.Picker.List,
+	}
+	for _, key := range sortedKeys(required) {
+		if strings.TrimSpace(required[key]) == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
 	}
-	log.Println("app config loaded")
 
-	return nil
+	if a.Scraper.Timeout <= 0 {
+		errs = append(errs, errors.New("app.scraper.timeout must be positive"))
+	}
+	if a.Scraper.Rate < 0 || a.Scraper.Backoff < 0 || a.Scraper.Retries < 0 {
+		errs = append(errs, errors.New("app.scraper.rate, backoff and retries must not be negative"))
+	}
+	if a.Scraper.PageSize <= 0 {
+		errs = append(errs, errors.New("app.scraper.page_size must be positive"))
+	}
+	if a.Storage.SnapshotRetention < 0 {
+		errs = append(errs, errors.New("app.storage.snapshot_retention must not be negative"))
+	}
+	if a.Picker.Count <= 0 {
+		errs = append(errs, errors.New("app.picker.count must be positive"))
+	}
+	for _, difficulty := range a.Picker.Difficulty {
+		if !contains([]string{"EASY", "MEDIUM", "HARD"}, strings.ToUpper(difficulty)) {
+			errs = append(errs, fmt.Errorf("app.picker.difficulty: unknown difficulty %q", difficulty))
+		}
+	}
+	errs = append(errs, a.validateProfiles()...)
+	return errors.Join(errs...)
 }
 
-var appOnce = sync.Once{}
-var appConfig *Application
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
 
-func GetApp(filename string) *Application {
-	appOnce.Do(func() {
-		loadApp(filename)
-	})
-	return appConfig
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
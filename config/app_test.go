@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFormats(t *testing.T) {
+	for name, content := range map[string]string{
+		"config.yaml": "app:\n  secrets:\n    file: custom.cookie\n",
+		"config.toml": "[app.secrets]\nfile = \"custom.cookie\"\n",
+		"config.json": `{"app": {"secrets": {"file": "custom.cookie"}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			app, err := Load(writeConfig(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if app.Secrets.File != "custom.cookie" {
+				t.Errorf("secrets file is %q", app.Secrets.File)
+			}
+		})
+	}
+}
+
+func TestLoadDefaultsAndEnv(t *testing.T) {
+	app, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Secrets.File != DefaultSecretsFile {
+		t.Errorf("secrets file defaults to %q", app.Secrets.File)
+	}
+
+	t.Setenv(EnvPrefix+"_APP_SECRETS_FILE", "from-env.cookie")
+	app, err = Load(writeConfig(t, "config.yaml", "app:\n  secrets:\n    file: custom.cookie\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Secrets.File != "from-env.cookie" {
+		t.Errorf("the environment must override the file, got %q", app.Secrets.File)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: got %v", err)
+	}
+	if _, err := Load(writeConfig(t, "config.ini", "")); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("unknown format: got %v", err)
+	}
+	if _, err := Load(writeConfig(t, "config.yaml", "app: [unclosed")); err == nil {
+		t.Error("malformed file: expected an error")
+	}
+	if _, err := Load(writeConfig(t, "config.yaml", "app:\n  secrets:\n    file: \"\"\n")); err == nil || !strings.Contains(err.Error(), "app.secrets.file is required") {
+		t.Errorf("validation: got %v", err)
+	}
+}
+
+func TestLoadExpandedSchema(t *testing.T) {
+	app, err := Load(writeConfig(t, "config.yaml", `app:
+  scraper:
+    timeout: 5s
+    rate: 250ms
+  storage:
+    snapshot_retention: 7
+  picker:
+    difficulty: [hard]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Scraper.Timeout != 5*time.Second || app.Scraper.Rate != 250*time.Millisecond {
+		t.Errorf("durations not decoded: %+v", app.Scraper)
+	}
+	if app.Scraper.Retries != 3 || app.Storage.DataDir != "updated_data" || app.Export.Deck != "LeetCode" {
+		t.Error("missing keys must keep their defaults")
+	}
+	if app.Storage.SnapshotRetention != 7 || len(app.Picker.Difficulty) != 1 {
+		t.Errorf("unexpected values %+v %+v", app.Storage, app.Picker)
+	}
+
+	t.Setenv(EnvPrefix+"_APP_PICKER_DIFFICULTY", "EASY,MEDIUM")
+	app, err = Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(app.Picker.Difficulty) != 2 || app.Picker.Difficulty[1] != "MEDIUM" {
+		t.Errorf("list override from the environment: %v", app.Picker.Difficulty)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	_, err := Load(writeConfig(t, "config.yaml", `app:
+  scraper:
+    timeout: 0s
+    page_size: 0
+  picker:
+    difficulty: [impossible]
+`))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"timeout must be positive", "page_size must be positive", `unknown difficulty "impossible"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("%q missing from %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultSite is the site requests go to unless a profile picks another one
+const DefaultSite = "leetcode.com"
+
+// Sites maps the supported LeetCode sites onto their GraphQL endpoints
+var Sites = map[string]string{
+	"leetcode.com": "https://leetcode.com/graphql/",
+	"leetcode.cn":  "https://leetcode.cn/graphql/",
+}
+
+// Profile is one user of the tool, with their own session, site and progress. Empty
+// fields keep the value of the surrounding config.
+type Profile struct {
+	// Username is the public LeetCode username, for data that needs no session
+	Username string `mapstructure:"username"`
+	// CookieFile holds the session of this user, see LoadCredentials
+	CookieFile string `mapstructure:"cookie_file"`
+	Site       string `mapstructure:"site"`
+	Solved     string `mapstructure:"solved"`
+	Daily      string `mapstructure:"daily"`
+	// Companies restricts commands working on every company list to these companies
+	Companies []string `mapstructure:"companies"`
+}
+
+// ProfileNames returns the names of the configured profiles, sorted
+func (a *Application) ProfileNames() []string {
+	names := make([]string, 0, len(a.Profiles))
+	for name := range a.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns a copy of the config with a profile applied on top of it; an
+// empty name selects the default profile, or none when no default is configured.
+// The receiver is left untouched, so several profiles can be used side by side, and
+// applying a profile to a config that already has one replaces it.
+func (a *Application) WithProfile(name string) (*Application, error) {
+	if a.base != nil {
+		return a.base.WithProfile(name)
+	}
+	if name == "" {
+		name = a.DefaultProfile
+	}
+	c := *a
+	if name == "" {
+		return &c, nil
+	}
+
+	// viper lower cases keys, profile names are case insensitive
+	name = strings.ToLower(name)
+	p, ok := a.Profiles[name]
+	if !ok {
+		if len(a.Profiles) == 0 {
+			return nil, fmt.Errorf("unknown profile %q, no profiles are configured", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q, expected one of %s", name, strings.Join(a.ProfileNames(), ", "))
+	}
+
+	c.profile = name
+	c.base = a
+	if p.CookieFile != "" {
+		c.Secrets.File = p.CookieFile
+	}
+	if p.Solved != "" {
+		c.Storage.Solved = p.Solved
+	}
+	if p.Daily != "" {
+		c.Storage.Daily = p.Daily
+	}
+	if p.Site != "" && p.Site != DefaultSite {
+		c.Scraper.BaseURL = Sites[p.Site]
+		// responses of different sites must not share cache entries
+		c.Storage.CacheDir = filepath.Join(c.Storage.CacheDir, p.Site)
+	}
+	return &c, nil
+}
+
+// Profile returns the name of the applied profile, empty when none is
+func (a *Application) Profile() string {
+	return a.profile
+}
+
+// SiteURL returns the root of the site requests go to, e.g. https://leetcode.cn/,
+// for links printed to the user. Falls back to the default site when the base
+// URL cannot be parsed.
+func (a *Application) SiteURL() string {
+	u, err := url.Parse(a.Scraper.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "https://" + DefaultSite + "/"
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
+// Companies returns the companies of the applied profile, nil for every company
+func (a *Application) Companies() []string {
+	if a.profile == "" {
+		return nil
+	}
+	return a.Profiles[a.profile].Companies
+}
+
+// CookieEnvFor returns the environment variable holding the cookie of a profile,
+// e.g. LEETCODE_COOKIE_ALICE; the default profile also reads LEETCODE_COOKIE
+func CookieEnvFor(profile string) string {
+	if profile == "" {
+		return CookieEnv
+	}
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, profile)
+	return CookieEnv + "_" + strings.ToUpper(name)
+}
+
+// cookieEnvs lists the environment variables LoadCredentials reads, in order
+func (a *Application) cookieEnvs() []string {
+	if a == nil || a.profile == "" {
+		return []string{CookieEnv}
+	}
+	envs := []string{CookieEnvFor(a.profile)}
+	if strings.EqualFold(a.profile, a.DefaultProfile) {
+		envs = append(envs, CookieEnv)
+	}
+	return envs
+}
+
+// validateProfiles reports profiles naming unknown sites and a missing default profile
+func (a *Application) validateProfiles() []error {
+	var errs []error
+	if a.DefaultProfile != "" {
+		if _, ok := a.Profiles[strings.ToLower(a.DefaultProfile)]; !ok {
+			errs = append(errs, fmt.Errorf("app.default_profile: unknown profile %q", a.DefaultProfile))
+		}
+	}
+	for _, name := range a.ProfileNames() {
+		if site := a.Profiles[name].Site; site != "" {
+			if _, ok := Sites[site]; !ok {
+				errs = append(errs, fmt.Errorf("app.profiles.%s.site: unknown site %q, expected leetcode.com or leetcode.cn", name, site))
+			}
+		}
+	}
+	return errs
+}
+
+// lookupEnv returns the first non-empty environment variable of names
+func lookupEnv(names []string) (name, value string) {
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return name, value
+		}
+	}
+	return "", ""
+}
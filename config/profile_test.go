@@ -0,0 +1,117 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `app:
+  default_profile: alice
+  profiles:
+    alice:
+      cookie_file: alice.cookie
+      solved: data/alice.txt
+      companies: [google]
+    bob:
+      cookie_file: bob.cookie
+      site: leetcode.cn
+      solved: data/bob.txt
+`
+
+func TestWithProfile(t *testing.T) {
+	app, err := Load(writeConfig(t, "config.yaml", profilesConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := app.WithProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := app.WithProfile("Bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if alice.Profile() != "alice" || alice.Secrets.File != "alice.cookie" || alice.Storage.Solved != "data/alice.txt" {
+		t.Errorf("default profile not applied: %+v", alice)
+	}
+	if len(alice.Companies()) != 1 || alice.Scraper.BaseURL != "https://leetcode.com/graphql/" {
+		t.Errorf("alice: companies %v, site %s", alice.Companies(), alice.Scraper.BaseURL)
+	}
+	if bob.Storage.Solved != "data/bob.txt" || bob.Scraper.BaseURL != Sites["leetcode.cn"] {
+		t.Errorf("bob not applied: %+v", bob)
+	}
+	if alice.SiteURL() != "https://leetcode.com/" || bob.SiteURL() != "https://leetcode.cn/" {
+		t.Errorf("links must point at the profile's site, got %s and %s", alice.SiteURL(), bob.SiteURL())
+	}
+	if bob.Storage.CacheDir != filepath.Join(".cache/leetcode", "leetcode.cn") {
+		t.Errorf("another site must use its own cache, got %s", bob.Storage.CacheDir)
+	}
+	if app.Profile() != "" || app.Secrets.File != DefaultSecretsFile {
+		t.Error("WithProfile must not modify the loaded config")
+	}
+	if switched, err := bob.WithProfile("alice"); err != nil || switched.Storage.CacheDir != alice.Storage.CacheDir {
+		t.Errorf("switching profiles must start from the loaded config, got %+v, %v", switched, err)
+	}
+
+	if _, err := app.WithProfile("carol"); err == nil || !strings.Contains(err.Error(), "alice, bob") {
+		t.Errorf("unknown profile: got %v", err)
+	}
+}
+
+func TestProfileCookieEnv(t *testing.T) {
+	app, err := Load(writeConfig(t, "config.yaml", profilesConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := app.WithProfile("alice")
+	bob, _ := app.WithProfile("bob")
+
+	t.Setenv(CookieEnv, "LEETCODE_SESSION=shared")
+	t.Setenv(CookieEnvFor("bob"), "LEETCODE_SESSION=bob")
+
+	for _, tt := range []struct {
+		app    *Application
+		cookie string
+		source string
+	}{
+		{alice, "LEETCODE_SESSION=shared", "LEETCODE_COOKIE"},
+		{bob, "LEETCODE_SESSION=bob", "LEETCODE_COOKIE_BOB"},
+	} {
+		creds, err := LoadCredentials(tt.app, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if creds.Cookie.Reveal() != tt.cookie || creds.Source != tt.source {
+			t.Errorf("%s: got %s from %s", tt.app.Profile(), creds.Cookie.Reveal(), creds.Source)
+		}
+	}
+
+	t.Setenv(CookieEnvFor("bob"), "")
+	creds, err := LoadCredentials(bob, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Cookie != "" {
+		t.Errorf("only the default profile may read %s, bob got one from %s", CookieEnv, creds.Source)
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	_, err := Load(writeConfig(t, "config.yaml", `app:
+  default_profile: nobody
+  profiles:
+    alice:
+      site: example.com
+`))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{`unknown profile "nobody"`, `unknown site "example.com"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("%q missing from %v", want, err)
+		}
+	}
+}
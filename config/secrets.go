@@ -0,0 +1,205 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// CookieEnv is the environment variable holding the LeetCode cookie
+const CookieEnv = "LEETCODE_COOKIE"
+
+// DefaultSecretsFile is where the cookie is read from when neither a cookie file
+// nor the environment provide one; it must not be tracked by git
+const DefaultSecretsFile = ".secrets/leetcode.cookie"
+
+const redacted = "[REDACTED]"
+
+// Secret is a credential that never prints its value; use Reveal to read it
+type Secret string
+
+// Reveal returns the secret value
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String implements fmt.Stringer
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString implements fmt.GoStringer, used by %#v
+func (s Secret) GoString() string {
+	return fmt.Sprintf("%q", s.String())
+}
+
+// MarshalText keeps secrets out of JSON and YAML output
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Credentials authenticate requests to LeetCode
+type Credentials struct {
+	Cookie Secret
+	// Source tells where the cookie was found, e.g. the environment or a file name
+	Source string
+}
+
+// secretPattern matches the session cookies LeetCode sets
+var secretPattern = regexp.MustCompile(`(?i)(LEETCODE_SESSION|csrftoken)=[^;\s"']+`)
+
+// LoadCredentials finds the LeetCode cookie. The sources are, in order: cookieFile
+// when given, the environment and the secrets file of the config. The environment
+// variable is LEETCODE_COOKIE, or LEETCODE_COOKIE_<PROFILE> once a profile is applied,
+// the default profile reading both. Files must only be readable by their owner and must not be tracked by git;
+// a tracked config holding a cookie is refused as well. No cookie at all is not an
+// error, requests are then anonymous.
+func LoadCredentials(app *Application, cookieFile string) (*Credentials, error) {
+	if app != nil && app.file != "" {
+		if err := refuseTrackedSecrets(app.file); err != nil {
+			return nil, err
+		}
+	}
+
+	env, value := lookupEnv(app.cookieEnvs())
+	var creds *Credentials
+	switch {
+	case cookieFile != "":
+		cookie, err := readSecretFile(cookieFile)
+		if err != nil {
+			return nil, err
+		}
+		creds = &Credentials{Cookie: cookie, Source: cookieFile}
+	case value != "":
+		creds = &Credentials{Cookie: Secret(value), Source: env}
+	default:
+		secretsFile := DefaultSecretsFile
+		if app != nil && app.Secrets.File != "" {
+			secretsFile = app.Secrets.File
+		}
+		if _, err := os.Stat(secretsFile); os.IsNotExist(err) {
+			return &Credentials{}, nil
+		}
+		cookie, err := readSecretFile(secretsFile)
+		if err != nil {
+			return nil, err
+		}
+		creds = &Credentials{Cookie: cookie, Source: secretsFile}
+	}
+
+	registerSecret(creds.Cookie)
+	return creds, nil
+}
+
+// readSecretFile reads a cookie from a file that is private and untracked; blank
+// lines and lines starting with # are ignored
+func readSecretFile(filename string) (Secret, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cookie file: %w", err)
+	}
+	// Windows has no permission bits to check
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("%s is accessible by other users (mode %s), run chmod 600 %s", filename, info.Mode().Perm(), filename)
+	}
+	if tracked(filename) {
+		return "", fmt.Errorf("%s is tracked by git, remove it with git rm --cached and rotate the session", filename)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cookie file: %w", err)
+	}
+	var parts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			parts = append(parts, line)
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%s holds no cookie", filename)
+	}
+	return Secret(strings.Join(parts, "; ")), nil
+}
+
+// refuseTrackedSecrets fails when a file tracked by git contains session cookies
+func refuseTrackedSecrets(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+	if secretPattern.Match(data) && tracked(filename) {
+		return fmt.Errorf("%s is tracked by git and contains LeetCode cookies; move them to %s or %s and rotate the session", filename, DefaultSecretsFile, CookieEnv)
+	}
+	return nil
+}
+
+// tracked reports whether git tracks the file; outside a repository nothing is tracked
+func tracked(filename string) bool {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return false
+	}
+	cmd := exec.Command("git", "ls-files", "--error-unmatch", filepath.Base(abs))
+	cmd.Dir = filepath.Dir(abs)
+	return cmd.Run() == nil
+}
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+)
+
+// registerSecret makes Redact hide the value and every cookie it is made of
+func registerSecret(s Secret) {
+	if s == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	secrets = append(secrets, s.Reveal())
+	for _, part := range strings.Split(s.Reveal(), ";") {
+		if _, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok && len(value) >= 8 {
+			secrets = append(secrets, value)
+		}
+	}
+}
+
+// Redact hides loaded credentials and anything shaped like a session cookie in s
+func Redact(s string) string {
+	secretsMu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	secretsMu.RUnlock()
+
+	return secretPattern.ReplaceAllString(s, "$1="+redacted)
+}
+
+type redactingWriter struct {
+	w io.Writer
+}
+
+// RedactingWriter returns a writer passing everything through Redact, e.g. for log.SetOutput
+func RedactingWriter(w io.Writer) io.Writer {
+	return redactingWriter{w: w}
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	clean := Redact(string(p))
+	if _, err := io.Copy(r.w, bytes.NewBufferString(clean)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretNeverPrints(t *testing.T) {
+	creds := Credentials{Cookie: "LEETCODE_SESSION=abcdefgh12345678", Source: "test"}
+
+	data, err := json.Marshal(creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{fmt.Sprint(creds), fmt.Sprintf("%+v %#v %s", creds, creds, creds.Cookie), string(data)} {
+		if strings.Contains(out, "abcdefgh") {
+			t.Errorf("secret leaked: %s", out)
+		}
+	}
+	if creds.Cookie.Reveal() != "LEETCODE_SESSION=abcdefgh12345678" {
+		t.Error("Reveal must return the value")
+	}
+}
+
+func TestLoadCredentialsFromCookieFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "leetcode.cookie")
+	content := "# session of the team account\nLEETCODE_SESSION=session-value-123\ncsrftoken=csrf-value-456\n"
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadCredentials(nil, filename); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Fatalf("a world readable cookie file must be refused, got %v", err)
+	}
+
+	if err := os.Chmod(filename, 0600); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := LoadCredentials(nil, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := creds.Cookie.Reveal(); got != "LEETCODE_SESSION=session-value-123; csrftoken=csrf-value-456" {
+		t.Errorf("got cookie %q", got)
+	}
+
+	var buf bytes.Buffer
+	logger := log.New(RedactingWriter(&buf), "", 0)
+	logger.Printf("request failed with cookie %s and token csrf-value-456", creds.Cookie.Reveal())
+	if strings.Contains(buf.String(), "value-") {
+		t.Errorf("log leaked a secret: %s", buf.String())
+	}
+}
+
+func TestRedactUnknownCookies(t *testing.T) {
+	got := Redact(`Cookie: LEETCODE_SESSION=eyJhbGciOi; csrftoken=Zm9vYmFy`)
+	if strings.Contains(got, "eyJhbGciOi") || strings.Contains(got, "Zm9vYmFy") {
+		t.Errorf("cookies were not redacted: %s", got)
+	}
+}
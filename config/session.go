@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cookie names LeetCode relies on
+const (
+	SessionCookie   = "LEETCODE_SESSION"
+	CSRFCookie      = "csrftoken"
+	ClearanceCookie = "cf_clearance"
+)
+
+// Cookie is one name=value pair of a cookie header
+type Cookie struct {
+	Name  string
+	Value Secret
+}
+
+// Session is a LeetCode cookie split into its parts
+type Session struct {
+	// LeetCodeSession is the JWT identifying the signed in user
+	LeetCodeSession Secret
+	// CSRFToken must be echoed in the x-csrftoken header of every request
+	CSRFToken Secret
+	// CFClearance is set by Cloudflare once its browser check passed
+	CFClearance Secret
+	// Other holds the remaining cookies in their original order
+	Other []Cookie
+}
+
+// ParseCookie splits a cookie header into a session; pairs without a name are dropped
+func ParseCookie(cookie string) *Session {
+	s := &Session{}
+	for _, pair := range strings.Split(cookie, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		name, value = strings.TrimSpace(name), strings.Trim(strings.TrimSpace(value), `"`)
+		if name == "" {
+			continue
+		}
+
+		switch name {
+		case SessionCookie:
+			s.LeetCodeSession = Secret(value)
+		case CSRFCookie:
+			s.CSRFToken = Secret(value)
+		case ClearanceCookie:
+			s.CFClearance = Secret(value)
+		default:
+			s.Other = append(s.Other, Cookie{Name: name, Value: Secret(value)})
+		}
+	}
+	return s
+}
+
+// Session splits the cookie of the credentials into its parts
+func (c *Credentials) Session() *Session {
+	return ParseCookie(c.Cookie.Reveal())
+}
+
+// Header rebuilds the cookie header, named parts first
+func (s *Session) Header() string {
+	var pairs []string
+	for _, c := range append([]Cookie{
+		{Name: SessionCookie, Value: s.LeetCodeSession},
+		{Name: CSRFCookie, Value: s.CSRFToken},
+		{Name: ClearanceCookie, Value: s.CFClearance},
+	}, s.Other...) {
+		if c.Value != "" {
+			pairs = append(pairs, c.Name+"="+c.Value.Reveal())
+		}
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// Expiry decodes when the session JWT expires. LeetCode tokens carry either a
+// standard exp claim or refreshed_at plus a _session_expiry lifetime in seconds.
+func (s *Session) Expiry() (time.Time, error) {
+	if s.LeetCodeSession == "" {
+		return time.Time{}, fmt.Errorf("no %s cookie", SessionCookie)
+	}
+
+	parts := strings.Split(s.LeetCodeSession.Reveal(), ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("%s is not a JWT", SessionCookie)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode %s: %w", SessionCookie, err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse %s claims: %w", SessionCookie, err)
+	}
+
+	if exp, ok := claimSeconds(claims, "exp"); ok {
+		return time.Unix(exp, 0), nil
+	}
+	refreshed, okRefreshed := claimSeconds(claims, "refreshed_at")
+	lifetime, okLifetime := claimSeconds(claims, "_session_expiry")
+	if okRefreshed && okLifetime {
+		return time.Unix(refreshed+lifetime, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("%s carries no expiry", SessionCookie)
+}
+
+// claimSeconds reads a numeric claim, which LeetCode sometimes encodes as a string
+func claimSeconds(claims map[string]interface{}, name string) (int64, bool) {
+	switch v := claims[name].(type) {
+	case float64:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func jwt(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(claims)) + ".signature"
+}
+
+func TestParseCookie(t *testing.T) {
+	token := jwt(`{"_auth_user_id":"42","refreshed_at":1700000000,"_session_expiry":1209600}`)
+	s := ParseCookie(`_ga=GA1.2; LEETCODE_SESSION=` + token + `; csrftoken="abc123"; cf_clearance=cf-ok; INGRESSCOOKIE=x`)
+
+	if s.LeetCodeSession.Reveal() != token || s.CSRFToken.Reveal() != "abc123" || s.CFClearance.Reveal() != "cf-ok" {
+		t.Fatalf("named parts not parsed: %#v", s)
+	}
+	if len(s.Other) != 2 || s.Other[0].Name != "_ga" || s.Other[1].Name != "INGRESSCOOKIE" {
+		t.Errorf("unexpected other cookies %+v", s.Other)
+	}
+	if header := s.Header(); !strings.HasPrefix(header, "LEETCODE_SESSION="+token+"; csrftoken=abc123; cf_clearance=cf-ok; _ga=") {
+		t.Errorf("unexpected header %s", header)
+	}
+
+	expiry, err := s.Expiry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Unix(1700000000+1209600, 0); !expiry.Equal(want) {
+		t.Errorf("expiry is %s, want %s", expiry, want)
+	}
+}
+
+func TestExpiryFromExpClaim(t *testing.T) {
+	s := ParseCookie("LEETCODE_SESSION=" + jwt(`{"exp":"1800000000"}`))
+	expiry, err := s.Expiry()
+	if err != nil || expiry.Unix() != 1800000000 {
+		t.Errorf("got %s, %v", expiry, err)
+	}
+
+	if _, err := ParseCookie("LEETCODE_SESSION=opaque").Expiry(); err == nil {
+		t.Error("a session that is not a JWT has no known expiry")
+	}
+}
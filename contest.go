@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// contestPlan is a past contest whose problems appear in tracked company lists
+type contestPlan struct {
+	Contest   scrapper.Contest
+	Questions []scrapper.ContestQuestion
+	// Companies maps the title slugs of tracked problems onto the companies asking them
+	Companies map[string][]string
+}
+
+// companyIndex maps the title slug of every problem of the lists onto the companies asking it
+func companyIndex(repo scrapper.Repository, slugs []string, pageSize int) (map[string][]string, error) {
+	index := make(map[string][]string)
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		company := registry.CompanyOf(slug)
+		for _, q := range response.Data.FavoriteQuestionList.Questions {
+			if !slices.Contains(index[q.TitleSlug], company) {
+				index[q.TitleSlug] = append(index[q.TitleSlug], company)
+			}
+		}
+	}
+	return index, nil
+}
+
+func runContest(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, expected one of upcoming, past, history, plan")
+	}
+
+	fs := flag.NewFlagSet("contest "+args[0], flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	count := fs.Int("count", 10, "number of past contests listed or searched")
+	minimum := fs.Int("min", 2, "problems a planned contest must share with the tracked lists")
+	if err := rf.parse(fs, args[1:]); err != nil {
+		return err
+	}
+
+	scraper, err := rf.scraper()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "upcoming":
+		contests, err := scraper.GetUpcomingContests()
+		if err != nil {
+			return err
+		}
+		for _, c := range contests {
+			fmt.Printf("%s  %-30s %s\n", c.Start().Format("2006-01-02 15:04"), c.Title, time.Duration(c.Duration)*time.Second)
+		}
+
+	case "past":
+		contests, total, err := scraper.GetPastContests(1, *count)
+		if err != nil {
+			return err
+		}
+		for _, c := range contests {
+			fmt.Printf("%s  %-30s %s\n", c.Start().Format(time.DateOnly), c.Title, c.TitleSlug)
+		}
+		fmt.Printf("%d of %d past contests\n", len(contests), total)
+
+	case "history":
+		usernames := fs.Args()
+		if len(usernames) == 0 {
+			usernames = profileUsernames(rf.config)
+		}
+		if len(usernames) == 0 {
+			return fmt.Errorf("no usernames given, pass them or set app.profiles.<name>.username")
+		}
+		for _, username := range usernames {
+			history, err := scraper.GetUserContestHistory(username)
+			if err != nil {
+				return err
+			}
+			printContestHistory(username, history)
+		}
+
+	case "plan":
+		repo, err := rf.repository()
+		if err != nil {
+			return err
+		}
+		slugs, err := listSlugs(repo, fs.Args(), rf.config.Companies())
+		if err != nil {
+			return err
+		}
+		index, err := companyIndex(repo, slugs, rf.config.Scraper.PageSize)
+		if err != nil {
+			return err
+		}
+		contests, _, err := scraper.GetPastContests(1, *count)
+		if err != nil {
+			return err
+		}
+
+		var plans []contestPlan
+		for _, c := range contests {
+			questions, err := scraper.GetContestQuestions(c.TitleSlug)
+			if err != nil {
+				return err
+			}
+			plan := contestPlan{Contest: c, Questions: questions, Companies: make(map[string][]string)}
+			for _, q := range questions {
+				if companies, ok := index[q.TitleSlug]; ok {
+					plan.Companies[q.TitleSlug] = companies
+				}
+			}
+			if len(plan.Companies) >= *minimum {
+				plans = append(plans, plan)
+			}
+		}
+		sort.SliceStable(plans, func(i, j int) bool {
+			return len(plans[i].Companies) > len(plans[j].Companies)
+		})
+		printContestPlans(plans, len(contests), rf.config.SiteURL())
+
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected one of upcoming, past, history, plan", args[0])
+	}
+	return nil
+}
+
+func printContestHistory(username string, history *scrapper.UserContestHistoryResponse) {
+	ranking := history.Data.UserContestRanking
+	if ranking == nil {
+		fmt.Printf("%s has not taken part in any contest\n", username)
+		return
+	}
+	fmt.Printf("%s: rating %.0f, %d contests, ranked %d (top %.1f%%)\n", username, ranking.Rating, ranking.AttendedContestsCount, ranking.GlobalRanking, ranking.TopPercentage)
+	for _, result := range history.Attended() {
+		fmt.Printf("  %s  %-30s %d/%d solved  rank %-6d rating %.0f\n",
+			time.Unix(result.Contest.StartTime, 0).Format(time.DateOnly), result.Contest.Title,
+			result.ProblemsSolved, result.TotalProblems, result.Ranking, result.Rating)
+	}
+}
+
+func printContestPlans(plans []contestPlan, searched int, siteURL string) {
+	if len(plans) == 0 {
+		fmt.Printf("None of the last %d contests has enough problems from the tracked lists\n", searched)
+		return
+	}
+	for _, plan := range plans {
+		fmt.Printf("%s (%s): %d of %d problems in tracked lists\n", plan.Contest.Title, plan.Contest.Start().Format(time.DateOnly), len(plan.Companies), len(plan.Questions))
+		for i, q := range plan.Questions {
+			companies := "-"
+			if c, ok := plan.Companies[q.TitleSlug]; ok {
+				companies = strings.Join(c, ", ")
+			}
+			fmt.Printf("  Q%d %-50s %s\n", i+1, q.Title, companies)
+		}
+		fmt.Printf("  %scontest/%s/\n", siteURL, plan.Contest.TitleSlug)
+	}
+}
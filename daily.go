@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"sort"
+	"strings"
+)
+
+// listReference is a favorite list a problem appears in
+type listReference struct {
+	Slug      string
+	Frequency float64
+}
+
+// crossReference returns the lists among slugs that contain a problem, most frequent first
+func crossReference(repo scrapper.Repository, slugs []string, titleSlug string, pageSize int) ([]listReference, error) {
+	var refs []listReference
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range response.Data.FavoriteQuestionList.Questions {
+			if q.TitleSlug == titleSlug {
+				refs = append(refs, listReference{Slug: slug, Frequency: q.Frequency})
+				break
+			}
+		}
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].Frequency > refs[j].Frequency
+	})
+	return refs, nil
+}
+
+func runDaily(args []string) error {
+	fs := flag.NewFlagSet("daily", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	done := fs.Bool("done", false, "record today's challenge as completed")
+	logFile := fs.String("log", "data/daily.txt", "log of completed daily challenges, used for streaks")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs completed challenges are added to")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	scraper, err := rf.scraper()
+	if err != nil {
+		return err
+	}
+	challenge, err := scraper.GetDailyChallenge()
+	if err != nil {
+		return err
+	}
+	problem := challenge.Question.Question().Problem(scrapper.FromCatalog, "")
+
+	fmt.Printf("Daily challenge %s: %s (%s, %.0f%% acceptance)\n", challenge.Date, problem.Title, problem.Difficulty, problem.AcceptancePercent())
+	fmt.Println(problem.URL())
+	var topics []string
+	for _, tag := range problem.TopicTags {
+		topics = append(topics, tag.Name)
+	}
+	fmt.Printf("Topics: %s\n", strings.Join(topics, ", "))
+
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	slugs, err := listSlugs(repo, fs.Args(), rf.config.Companies())
+	if err != nil {
+		return err
+	}
+	refs, err := crossReference(repo, slugs, problem.TitleSlug, rf.config.Scraper.PageSize)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		fmt.Printf("Not in any of the %d tracked lists\n", len(slugs))
+	} else {
+		// refs are ordered by frequency, so are the companies
+		var companies []string
+		periods := make(map[string][]string)
+		for _, ref := range refs {
+			company, period := registry.Split(ref.Slug)
+			if _, ok := periods[company]; !ok {
+				companies = append(companies, company)
+			}
+			periods[company] = append(periods[company], fmt.Sprintf("%s %.1f", period, ref.Frequency))
+		}
+		fmt.Printf("Asked by %d of the tracked companies:\n", len(companies))
+		for _, company := range companies {
+			fmt.Printf("  %-12s %s\n", company, strings.Join(periods[company], ", "))
+		}
+	}
+
+	daily, err := progress.LoadDaily(*logFile)
+	if err != nil {
+		return err
+	}
+	// LeetCode knows when the signed in user already solved it
+	if *done || challenge.UserStatus == "Finish" {
+		if daily.Record(challenge.Date, problem.TitleSlug) {
+			if err := daily.Save(); err != nil {
+				return err
+			}
+			solved, err := progress.Load(*solvedFile)
+			if err != nil {
+				return err
+			}
+			if solved.Add(problem.TitleSlug) {
+				if err := solved.Save(); err != nil {
+					return err
+				}
+			}
+			fmt.Printf("Recorded %s as completed\n", challenge.Date)
+		}
+	}
+
+	current, longest := daily.Streak(challenge.Date)
+	status := "done"
+	if !daily.Done(challenge.Date) {
+		status = "not done yet, run with --done once solved"
+	}
+	fmt.Printf("Streak: %d days (longest %d), today %s\n", current, longest, status)
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"slices"
+	"strings"
+	"time"
+)
+
+// discoverCompany probes every time window of a company for a favorite list
+func discoverCompany(repo scrapper.Repository, tag scrapper.CompanyTag) (registry.Company, error) {
+	company := registry.Company{Slug: tag.Slug, Name: tag.Name, QuestionCount: tag.QuestionCount}
+	for _, window := range registry.Windows {
+		slug := registry.Slug(tag.Slug, window)
+		questions, ok, err := scrapper.ProbeFavoriteList(repo, slug)
+		if err != nil {
+			return company, err
+		}
+		if ok {
+			company.Lists = append(company.Lists, registry.List{Slug: slug, Period: window, Questions: questions})
+		}
+	}
+	return company, nil
+}
+
+func runDiscover(args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ExitOnError)
+	var sf scraperFlags
+	sf.register(fs)
+	top := fs.Int("top", 50, "number of most tagged companies probed for lists, 0 for all of them")
+	companySpec := fs.String("companies", "", "comma separated company slugs to probe instead of the most tagged ones")
+	outFile := fs.String("out", "data/companies.json", "file the company catalog is written to")
+	if err := sf.parse(fs, args); err != nil {
+		return err
+	}
+
+	scraper, err := sf.scraper()
+	if err != nil {
+		return err
+	}
+	tags, err := scraper.GetCompanyTags()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Found %d company tags\n", len(tags))
+
+	if wanted := scrapper.SplitList(*companySpec); len(wanted) > 0 {
+		tags = slices.DeleteFunc(tags, func(tag scrapper.CompanyTag) bool {
+			return !slices.Contains(wanted, tag.Slug)
+		})
+	} else if *top > 0 && len(tags) > *top {
+		tags = tags[:*top]
+	}
+
+	catalog := &registry.Catalog{Discovered: time.Now().UTC()}
+	lists := 0
+	for _, tag := range tags {
+		company, err := discoverCompany(scraper, tag)
+		if err != nil {
+			return err
+		}
+		catalog.Companies = append(catalog.Companies, company)
+		lists += len(company.Lists)
+
+		windows := make([]string, 0, len(company.Lists))
+		for _, list := range company.Lists {
+			windows = append(windows, fmt.Sprintf("%s %d", list.Period, list.Questions))
+		}
+		if len(windows) == 0 {
+			windows = append(windows, "no lists")
+		}
+		fmt.Printf("%-24s %5d questions  %s\n", company.Slug, company.QuestionCount, strings.Join(windows, ", "))
+	}
+
+	if lists == 0 {
+		return fmt.Errorf("no company lists found, %s is left unchanged", *outFile)
+	}
+	if err := catalog.Save(*outFile); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d lists of %d companies to %s, the bulk downloader now tracks them\n", lists, len(catalog.Companies), *outFile)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/config"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/snapshot"
+	"leetcode-scrapper/utils"
+	"path/filepath"
+)
+
+// downloadCompanyProblems saves a favorite list into outDir and, when storage retains
+// snapshots, a timestamped copy into the snapshot directory
+func downloadCompanyProblems(repo scrapper.Repository, favoriteSlug string, chunkSize int, outDir string, storage config.Storage, opts ...scrapper.QueryOption) error {
+	fmt.Println(fmt.Sprintf("Scraping %s list...", favoriteSlug))
+	favoriteResponse, err := scrapper.FetchFavoriteList(repo, favoriteSlug, chunkSize, opts...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Found %d problems in favorite list\n", len(favoriteResponse.Data.FavoriteQuestionList.Questions))
+
+	// Save favorite list problems
+	if err := utils.SaveToFile(favoriteResponse, filepath.Join(outDir, favoriteSlug+".json")); err != nil {
+		return fmt.Errorf("failed to save favorite list: %w", err)
+	}
+	if storage.SnapshotRetention > 0 {
+		if _, err := snapshot.Save(storage.SnapshotDir, favoriteSlug, favoriteResponse, storage.SnapshotRetention); err != nil {
+			return fmt.Errorf("failed to snapshot favorite list: %w", err)
+		}
+	}
+	return nil
+}
+
+func runDownload(args []string) error {
+	fs := flag.NewFlagSet("download", flag.ExitOnError)
+	var sf scraperFlags
+	sf.register(fs)
+	var qf queryFlags
+	qf.register(fs)
+	chunkSize := fs.Int("chunk", 10, "number of questions requested per page")
+	all := fs.Bool("all", false, "download every list of the tracked companies, or of the profile's companies")
+	outDir := fs.String("out", "updated_data", "directory the favorite lists are saved to")
+	if err := sf.parse(fs, args); err != nil {
+		return err
+	}
+
+	opts, err := qf.queryOptions()
+	if err != nil {
+		return err
+	}
+
+	slugs := fs.Args()
+	if *all {
+		slugs = registry.SlugsOf(sf.config.Companies())
+	}
+	if len(slugs) == 0 {
+		return fmt.Errorf("no favorite slugs given, pass slugs or --all")
+	}
+
+	leetcodeScrapper, err := sf.scraper()
+	if err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		if err := downloadCompanyProblems(leetcodeScrapper, slug, *chunkSize, *outDir, sf.config.Storage, opts...); err != nil {
+			fmt.Printf("Error scraping %s: %s\n", slug, config.Redact(err.Error()))
+			continue
+		}
+		fmt.Printf("Scraping %s completed\n", slug)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"leetcode-scrapper/export"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"os"
+	"strings"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	var qf queryFlags
+	qf.register(fs)
+	format := fs.String("format", "csv", "output format: csv, tsv, markdown or anki")
+	columnSpec := fs.String("columns", "", "comma separated columns to export, default all")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs merged into the status column")
+	groupBy := fs.String("group-by", export.GroupByTopic, "markdown grouping: topic, difficulty, list or none")
+	title := fs.String("title", "", "markdown heading, defaults to the exported lists")
+	deck := fs.String("deck", "LeetCode", "anki deck the notes are imported into")
+	outFile := fs.String("out", "", "file to write to, stdout when empty")
+	all := fs.Bool("all", false, "export every list of the tracked companies, or of the profile's companies")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	slugs := fs.Args()
+	if *all {
+		slugs = registry.SlugsOf(rf.config.Companies())
+	}
+	if len(slugs) == 0 {
+		return fmt.Errorf("no favorite slugs given, pass slugs or --all")
+	}
+
+	opts, err := qf.queryOptions()
+	if err != nil {
+		return err
+	}
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	solved, err := progress.Load(*solvedFile)
+	if err != nil {
+		return err
+	}
+
+	var problems []scrapper.Problem
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, rf.config.Scraper.PageSize, opts...)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, response.Problems(slug)...)
+	}
+	export.MarkSolved(problems, solved)
+
+	var w io.Writer = os.Stdout
+	if *outFile != "" {
+		file, err := os.Create(*outFile)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *outFile, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	switch *format {
+	case "csv", "tsv":
+		columns, err := export.ParseColumns(*columnSpec)
+		if err != nil {
+			return err
+		}
+		delimiter := ','
+		if *format == "tsv" {
+			delimiter = '\t'
+		}
+		return export.WriteDelimited(w, problems, columns, delimiter)
+	case "markdown", "md":
+		heading := *title
+		if heading == "" {
+			heading = "Study sheet: " + strings.Join(slugs, ", ")
+		}
+		return export.WriteMarkdown(w, problems, export.MarkdownOptions{Title: heading, GroupBy: *groupBy})
+	case "anki":
+		details := fetchDetails(repo, problems)
+		return export.WriteAnki(w, problems, details, export.AnkiOptions{Deck: *deck, Company: registry.CompanyOf})
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+// fetchDetails looks up the detail of every problem, skipping those that cannot be
+// fetched; offline that means problems whose details were never cached
+func fetchDetails(repo scrapper.Repository, problems []scrapper.Problem) map[string]*scrapper.ProblemDetailResponse {
+	details := make(map[string]*scrapper.ProblemDetailResponse)
+	missing := 0
+	for _, problem := range problems {
+		if _, ok := details[problem.TitleSlug]; ok {
+			continue
+		}
+		detail, err := repo.GetProblemDetail(problem.TitleSlug)
+		if err != nil {
+			if !errors.Is(err, scrapper.ErrNotAvailableOffline) {
+				fmt.Fprintf(os.Stderr, "Error getting details for %s: %v\n", problem.Title, err)
+			}
+			missing++
+			continue
+		}
+		details[problem.TitleSlug] = detail
+	}
+
+	if missing > 0 {
+		fmt.Fprintf(os.Stderr, "%d problems have no details, fetch them with --live\n", missing)
+	}
+	return details
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"leetcode-scrapper/scrapper"
+	"strings"
+)
+
+// AnkiOptions controls the deck written by WriteAnki
+type AnkiOptions struct {
+	// Deck is the name of the Anki deck the notes are imported into
+	Deck string
+	// Company maps a favorite list slug onto the company it belongs to
+	Company func(listSlug string) string
+}
+
+// WriteAnki writes problems as an Anki text import using the Basic note type: the
+// front holds the title and statement, the back the topics, hints and similar
+// questions. Notes are tagged with their companies, difficulty and topics. Details
+// are looked up by title slug; problems without details get a link instead of a
+// statement.
+func WriteAnki(w io.Writer, problems []scrapper.Problem, details map[string]*scrapper.ProblemDetailResponse, opts AnkiOptions) error {
+	deck := opts.Deck
+	if deck == "" {
+		deck = "LeetCode"
+	}
+
+	// Anki reads these headers to configure the import
+	header := fmt.Sprintf("#separator:tab\n#html:true\n#notetype:Basic\n#deck:%s\n#tags column:3\n#columns:Front\tBack\tTags\n", deck)
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+
+	for _, problem := range mergeLists(problems) {
+		detail := details[problem.TitleSlug]
+		record := []string{
+			ankiFront(problem, detail),
+			ankiBack(problem, detail),
+			strings.Join(ankiTags(problem, opts.Company), " "),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write %s: %w", problem.TitleSlug, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func ankiFront(p scrapper.Problem, detail *scrapper.ProblemDetailResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<h3><a href="%s">%d. %s</a></h3>`, p.URL(), p.FrontendID, html.EscapeString(p.Title))
+	fmt.Fprintf(&b, "<p>%s</p>", titleCase(p.Difficulty))
+
+	if detail != nil && detail.Data.Question.Content != "" {
+		b.WriteString(singleLine(detail.Data.Question.Content))
+	} else {
+		fmt.Fprintf(&b, `<p><a href="%s">Read the statement on LeetCode</a></p>`, p.URL())
+	}
+	return b.String()
+}
+
+func ankiBack(p scrapper.Problem, detail *scrapper.ProblemDetailResponse) string {
+	var b strings.Builder
+	if names := TopicNames(p); len(names) > 0 {
+		fmt.Fprintf(&b, "<p><b>Topics:</b> %s</p>", html.EscapeString(strings.Join(names, ", ")))
+	}
+	if detail == nil {
+		return b.String()
+	}
+
+	if hints := detail.Data.Question.Hints; len(hints) > 0 {
+		b.WriteString("<p><b>Hints:</b></p><ol>")
+		for _, hint := range hints {
+			fmt.Fprintf(&b, "<li>%s</li>", singleLine(hint))
+		}
+		b.WriteString("</ol>")
+	}
+
+	// A malformed similarQuestions field only costs the card that section
+	if similar, err := detail.SimilarQuestionList(); err == nil && len(similar) > 0 {
+		b.WriteString("<p><b>Similar questions:</b></p><ul>")
+		for _, s := range similar {
+			fmt.Fprintf(&b, `<li><a href="https://leetcode.com/problems/%s/">%s</a> (%s)</li>`, s.TitleSlug, html.EscapeString(s.Title), s.Difficulty)
+		}
+		b.WriteString("</ul>")
+	}
+	return b.String()
+}
+
+// ankiTags returns hierarchical tags; Anki separates tags with spaces so none may contain one
+func ankiTags(p scrapper.Problem, company func(string) string) []string {
+	tags := []string{"leetcode", "difficulty::" + strings.ToLower(p.Difficulty)}
+
+	seen := make(map[string]bool)
+	for _, list := range strings.Split(p.ListSlug, ", ") {
+		if list == "" {
+			continue
+		}
+		name := list
+		if company != nil {
+			name = company(list)
+		}
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, "company::"+name)
+		}
+	}
+
+	for _, tag := range p.TopicTags {
+		tags = append(tags, "topic::"+tag.Slug)
+	}
+	if p.PaidOnly {
+		tags = append(tags, "premium")
+	}
+	return tags
+}
+
+// singleLine flattens HTML onto one line so each note stays on one row of the import.
+// Line breaks are only significant inside <pre> blocks, where they become <br>.
+func singleLine(s string) string {
+	var b strings.Builder
+	inPre := false
+	for len(s) > 0 {
+		if strings.HasPrefix(s, "<pre>") {
+			inPre = true
+		} else if strings.HasPrefix(s, "</pre>") {
+			inPre = false
+		}
+
+		switch c := s[0]; c {
+		case '\r':
+		case '\n':
+			if inPre {
+				b.WriteString("<br>")
+			} else {
+				b.WriteByte(' ')
+			}
+		case '\t':
+			b.WriteString("    ")
+		default:
+			b.WriteByte(c)
+		}
+		s = s[1:]
+	}
+	return strings.TrimSpace(b.String())
+}
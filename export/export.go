@@ -0,0 +1,108 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"strconv"
+	"strings"
+)
+
+// Column is a single exported field of a problem
+type Column struct {
+	Name  string
+	Value func(p scrapper.Problem) string
+}
+
+// Columns holds every column that can be exported, keyed by name
+var Columns = map[string]Column{}
+
+// DefaultColumns are exported when no columns are selected
+var DefaultColumns = []string{"id", "title", "slug", "difficulty", "frequency", "acRate", "paidOnly", "status", "topics", "url"}
+
+func init() {
+	for _, column := range []Column{
+		{"id", func(p scrapper.Problem) string { return strconv.Itoa(p.FrontendID) }},
+		{"title", func(p scrapper.Problem) string { return p.Title }},
+		{"slug", func(p scrapper.Problem) string { return p.TitleSlug }},
+		{"difficulty", func(p scrapper.Problem) string { return p.Difficulty }},
+		{"frequency", func(p scrapper.Problem) string { return strconv.FormatFloat(p.Frequency, 'f', 2, 64) }},
+		{"acRate", func(p scrapper.Problem) string { return strconv.FormatFloat(p.AcceptancePercent(), 'f', 2, 64) }},
+		{"paidOnly", func(p scrapper.Problem) string { return strconv.FormatBool(p.PaidOnly) }},
+		{"status", func(p scrapper.Problem) string { return p.Status }},
+		{"topics", func(p scrapper.Problem) string { return strings.Join(TopicNames(p), ", ") }},
+		{"url", func(p scrapper.Problem) string { return p.URL() }},
+		{"list", func(p scrapper.Problem) string { return p.ListSlug }},
+	} {
+		Columns[column.Name] = column
+	}
+}
+
+// ParseColumns resolves a comma separated list of column names, DefaultColumns when empty
+func ParseColumns(spec string) ([]Column, error) {
+	names := DefaultColumns
+	if strings.TrimSpace(spec) != "" {
+		names = strings.Split(spec, ",")
+	}
+
+	columns := make([]Column, 0, len(names))
+	for _, name := range names {
+		column, ok := Columns[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// TopicNames returns the names of the topic tags of a problem
+func TopicNames(p scrapper.Problem) []string {
+	names := make([]string, 0, len(p.TopicTags))
+	for _, tag := range p.TopicTags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// MarkSolved sets the status of every problem found in the solved data to SOLVED
+func MarkSolved(problems []scrapper.Problem, solved *progress.Solved) {
+	if solved == nil {
+		return
+	}
+	for i := range problems {
+		if solved.Has(problems[i].TitleSlug) {
+			problems[i].Status = "SOLVED"
+		}
+	}
+}
+
+// WriteDelimited writes problems as delimiter separated values with a header row,
+// e.g. ',' for CSV and '\t' for TSV
+func WriteDelimited(w io.Writer, problems []scrapper.Problem, columns []Column, delimiter rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	record := make([]string, len(columns))
+	for _, problem := range problems {
+		for i, column := range columns {
+			record[i] = column.Value(problem)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write %s: %w", problem.TitleSlug, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"leetcode-scrapper/scrapper"
+	"sort"
+	"strings"
+)
+
+// Groupings understood by WriteMarkdown
+const (
+	GroupByTopic      = "topic"
+	GroupByDifficulty = "difficulty"
+	GroupByList       = "list"
+	GroupByNone       = "none"
+)
+
+// MarkdownOptions controls the layout of a study sheet
+type MarkdownOptions struct {
+	Title   string
+	GroupBy string
+}
+
+var difficultyOrder = []string{"EASY", "MEDIUM", "HARD"}
+
+// WriteMarkdown writes problems as a checklist grouped by topic, difficulty or list.
+// Problems appearing in several lists are listed once, naming every list, and solved
+// problems are ticked.
+func WriteMarkdown(w io.Writer, problems []scrapper.Problem, opts MarkdownOptions) error {
+	problems = mergeLists(problems)
+
+	solved := 0
+	for _, problem := range problems {
+		if problem.Status == "SOLVED" {
+			solved++
+		}
+	}
+
+	var b strings.Builder
+	if opts.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", opts.Title)
+	}
+	fmt.Fprintf(&b, "Solved %d of %d problems.\n", solved, len(problems))
+
+	groups, names, err := group(problems, opts.GroupBy)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		members := groups[name]
+		if name != "" {
+			fmt.Fprintf(&b, "\n## %s (%d)\n", name, len(members))
+		}
+		b.WriteString("\n")
+		for _, problem := range members {
+			writeChecklistItem(&b, problem)
+		}
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func writeChecklistItem(b *strings.Builder, p scrapper.Problem) {
+	check := " "
+	if p.Status == "SOLVED" {
+		check = "x"
+	}
+
+	fmt.Fprintf(b, "- [%s] [%d. %s](%s) · %s · `%s` %.1f", check, p.FrontendID, p.Title, p.URL(), titleCase(p.Difficulty), FrequencyBar(p.Frequency, 10), p.Frequency)
+	if p.PaidOnly {
+		b.WriteString(" · 🔒")
+	}
+	if p.ListSlug != "" {
+		fmt.Fprintf(b, " · %s", p.ListSlug)
+	}
+	b.WriteString("\n")
+}
+
+// FrequencyBar draws a frequency between 0 and 100 as a bar of the given width
+func FrequencyBar(frequency float64, width int) string {
+	filled := int(frequency/100*float64(width) + 0.5)
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// mergeLists collapses problems appearing in several lists into one entry whose
+// ListSlug names every list, keeping the highest frequency
+func mergeLists(problems []scrapper.Problem) []scrapper.Problem {
+	index := make(map[string]int)
+	var merged []scrapper.Problem
+	for _, problem := range problems {
+		i, ok := index[problem.TitleSlug]
+		if !ok {
+			index[problem.TitleSlug] = len(merged)
+			merged = append(merged, problem)
+			continue
+		}
+
+		existing := &merged[i]
+		if problem.ListSlug != "" && !containsString(strings.Split(existing.ListSlug, ", "), problem.ListSlug) {
+			existing.ListSlug += ", " + problem.ListSlug
+		}
+		if problem.Frequency > existing.Frequency {
+			existing.Frequency = problem.Frequency
+		}
+		if problem.Status == "SOLVED" {
+			existing.Status = problem.Status
+		}
+	}
+	return merged
+}
+
+// group splits problems into named groups, most frequent first, and returns the group
+// names in display order. Problems are grouped by their first topic tag so that each
+// appears exactly once.
+func group(problems []scrapper.Problem, groupBy string) (map[string][]scrapper.Problem, []string, error) {
+	groups := make(map[string][]scrapper.Problem)
+	var key func(p scrapper.Problem) string
+
+	switch groupBy {
+	case GroupByTopic:
+		key = func(p scrapper.Problem) string {
+			if len(p.TopicTags) == 0 {
+				return "Other"
+			}
+			return p.TopicTags[0].Name
+		}
+	case GroupByDifficulty:
+		key = func(p scrapper.Problem) string { return titleCase(p.Difficulty) }
+	case GroupByList:
+		key = func(p scrapper.Problem) string { return strings.SplitN(p.ListSlug, ", ", 2)[0] }
+	case GroupByNone, "":
+		key = func(p scrapper.Problem) string { return "" }
+	default:
+		return nil, nil, fmt.Errorf("unknown grouping %q, expected topic, difficulty, list or none", groupBy)
+	}
+
+	for _, problem := range problems {
+		name := key(problem)
+		groups[name] = append(groups[name], problem)
+	}
+	for _, members := range groups {
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].Frequency > members[j].Frequency
+		})
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	if groupBy == GroupByDifficulty {
+		sort.Slice(names, func(i, j int) bool {
+			return rank(names[i]) < rank(names[j])
+		})
+	} else {
+		// Largest groups first, then alphabetically
+		sort.Slice(names, func(i, j int) bool {
+			if len(groups[names[i]]) != len(groups[names[j]]) {
+				return len(groups[names[i]]) > len(groups[names[j]])
+			}
+			return names[i] < names[j]
+		})
+	}
+	return groups, names, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func rank(difficulty string) int {
+	for i, d := range difficultyOrder {
+		if strings.EqualFold(d, difficulty) {
+			return i
+		}
+	}
+	return len(difficultyOrder)
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
@@ -0,0 +1,234 @@
+// Package fixture records GraphQL exchanges with LeetCode into files and replays
+// them, so code built on the scraper can be tested without network or credentials
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"leetcode-scrapper/cache"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrNoRecording is returned when replaying a request that was never recorded
+var ErrNoRecording = errors.New("no recording")
+
+// secretHeaders are never written to a fixture
+var secretHeaders = []string{"Cookie", "X-Csrftoken", "Authorization"}
+
+const redacted = "REDACTED"
+
+// Exchange is one recorded request and its response
+type Exchange struct {
+	OperationName string            `json:"operationName"`
+	Variables     json.RawMessage   `json:"variables"`
+	Query         string            `json:"query"`
+	Headers       map[string]string `json:"headers"`
+	Status        int               `json:"status"`
+	Response      json.RawMessage   `json:"response"`
+}
+
+type graphQLRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables"`
+	OperationName string          `json:"operationName"`
+}
+
+// Filename returns the fixture file of an operation, named after the operation and
+// the same key the response cache uses, so a changed query needs a new recording
+func Filename(dir, operation, query string, variables interface{}) (string, error) {
+	key, err := cache.Key(operation, query, variables)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, operation+"-"+key[:12]+".json"), nil
+}
+
+// readRequest decodes a GraphQL request body and restores it for the next reader
+func readRequest(req *http.Request) (*graphQLRequest, error) {
+	if req.Body == nil {
+		return nil, fmt.Errorf("request has no body")
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var gql graphQLRequest
+	if err := json.Unmarshal(body, &gql); err != nil {
+		return nil, fmt.Errorf("failed to parse request body: %w", err)
+	}
+	if len(gql.Variables) == 0 {
+		gql.Variables = json.RawMessage("null")
+	}
+	return &gql, nil
+}
+
+// Recorder is an http.RoundTripper that forwards requests and writes every exchange
+// into a directory. Cookies and other credentials are replaced before writing.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+}
+
+// NewRecorder records into dir the exchanges made through next, the default
+// transport when nil
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	gql, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	exchange := Exchange{
+		OperationName: gql.OperationName,
+		Variables:     gql.Variables,
+		Query:         gql.Query,
+		Headers:       scrub(req.Header),
+		Status:        resp.StatusCode,
+		Response:      body,
+	}
+	if !json.Valid(body) {
+		// Keep non-JSON error pages readable as a JSON string
+		exchange.Response, _ = json.Marshal(string(body))
+	}
+	if err := r.write(exchange); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) write(exchange Exchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filename, err := Filename(r.dir, exchange.OperationName, exchange.Query, exchange.Variables)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+	if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// scrub flattens request headers, replacing credentials
+func scrub(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+	for _, name := range secretHeaders {
+		if _, ok := headers[name]; ok {
+			headers[name] = redacted
+		}
+	}
+	return headers
+}
+
+// Replayer is an http.RoundTripper answering requests from recorded fixtures
+type Replayer struct {
+	dir string
+	mu  sync.Mutex
+	// served counts the requests answered per fixture file
+	served map[string]int
+}
+
+// NewReplayer replays the fixtures recorded into dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir, served: make(map[string]int)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	gql, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filename, err := Filename(r.dir, gql.OperationName, gql.Query, gql.Variables)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s %s, expected %s: %w", gql.OperationName, gql.Variables, filename, ErrNoRecording)
+		}
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var exchange Exchange
+	if err := json.Unmarshal(data, &exchange); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	body := []byte(exchange.Response)
+	var text string
+	if json.Unmarshal(exchange.Response, &text) == nil {
+		body = []byte(text)
+	}
+
+	r.mu.Lock()
+	r.served[filepath.Base(filename)]++
+	r.mu.Unlock()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Status, http.StatusText(exchange.Status)),
+		StatusCode:    exchange.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Served returns the fixture files replayed so far, sorted
+func (r *Replayer) Served() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files := make([]string, 0, len(r.served))
+	for file := range r.served {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
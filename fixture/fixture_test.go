@@ -0,0 +1,118 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const secret = "LEETCODE_SESSION=very-secret; csrftoken=also-secret"
+
+func post(t *testing.T, client *http.Client, url, operation string, variables interface{}) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         "query " + operation + " { x }",
+		"variables":     variables,
+		"operationName": operation,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Cookie", secret)
+	req.Header.Set("X-Csrftoken", "also-secret")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+// compact strips formatting, fixtures are indented for review
+func compact(t *testing.T, s string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Cookie") != secret {
+			t.Errorf("the recorder must forward the cookie unchanged, got %q", r.Header.Get("Cookie"))
+		}
+		w.Write([]byte(`{"data":{"question":{"titleSlug":"two-sum"}}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: NewRecorder(dir, nil)}
+	variables := map[string]interface{}{"titleSlug": "two-sum"}
+	status, body := post(t, recording, server.URL, "questionData", variables)
+	if status != http.StatusOK || !strings.Contains(body, "two-sum") {
+		t.Fatalf("recording altered the response: %d %s", status, body)
+	}
+
+	filename, err := Filename(dir, "questionData", "query questionData { x }", variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("fixture leaks credentials:\n%s", data)
+	}
+
+	replaying := &http.Client{Transport: NewReplayer(dir)}
+	status, replayed := post(t, replaying, "http://replay.invalid/graphql/", "questionData", variables)
+	if status != http.StatusOK || compact(t, replayed) != compact(t, body) {
+		t.Errorf("replayed %d %s, want 200 %s", status, replayed, body)
+	}
+}
+
+func TestReplayKeepsErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	post(t, &http.Client{Transport: NewRecorder(dir, nil)}, server.URL, "questionData", nil)
+
+	status, body := post(t, &http.Client{Transport: NewReplayer(dir)}, server.URL, "questionData", nil)
+	if status != http.StatusTooManyRequests || strings.TrimSpace(body) != "slow down" {
+		t.Errorf("replayed %d %q", status, body)
+	}
+}
+
+func TestReplayMissingRecording(t *testing.T) {
+	client := &http.Client{Transport: NewReplayer(filepath.Join(t.TempDir(), "empty"))}
+	body := strings.NewReader(`{"operationName":"questionData","variables":{"titleSlug":"x"}}`)
+
+	_, err := client.Post("http://replay.invalid/graphql/", "application/json", body)
+	if !errors.Is(err, ErrNoRecording) {
+		t.Fatalf("got %v, want ErrNoRecording", err)
+	}
+}
@@ -1,144 +1,388 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"leetcode-scrapper/cache"
+	"leetcode-scrapper/config"
+	"leetcode-scrapper/fixture"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/registry"
 	"leetcode-scrapper/scrapper"
-	"leetcode-scrapper/utils"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-var chunkSize = 20
+// command is a CLI subcommand receiving the arguments that follow its name
+type command func(args []string) error
 
-func downloadCompanyProblems(favoriteSlug string) {
-	// Example 1: Scrape problems from a favorite list
-	fmt.Println(fmt.Sprintf("Scraping %s list...", favoriteSlug))
-	favoriteResponse, err := leetcodeScrapper.GetFavoriteQuestionList(favoriteSlug, 0, chunkSize)
+var commands = map[string]command{
+	"pick":      runPick,
+	"download":  runDownload,
+	"cache":     runCache,
+	"mirror":    runMirror,
+	"export":    runExport,
+	"report":    runReport,
+	"serve":     runServe,
+	"whoami":    runWhoami,
+	"team":      runTeam,
+	"user":      runUser,
+	"daily":     runDaily,
+	"contest":   runContest,
+	"push-list": runPushList,
+	"discover":  runDiscover,
+}
+
+const defaultCommand = "pick"
+
+const defaultCatalogFile = "data/catalog.json"
+
+const defaultConfigFile = "config.yaml"
+
+// scraperFlags are the flags shared by every command that talks to LeetCode
+type scraperFlags struct {
+	configFile string
+	cacheDir   string
+	noCache    bool
+	offline    bool
+	refresh    bool
+	record     string
+	cookieFile string
+	profile    string
+
+	// public commands only read public data and need no session
+	public bool
+	// config is loaded by parse
+	config *config.Application
+}
+
+func (f *scraperFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.configFile, "config", defaultConfigFile, "config file, YAML, TOML or JSON")
+	fs.StringVar(&f.cacheDir, "cache-dir", ".cache/leetcode", "directory of the response cache")
+	fs.BoolVar(&f.noCache, "no-cache", false, "bypass the response cache entirely")
+	fs.BoolVar(&f.offline, "offline", false, "serve responses only from the cache, never from the network")
+	fs.BoolVar(&f.refresh, "refresh", false, "ignore cached responses and refetch everything")
+	fs.StringVar(&f.record, "record", "", "write every exchange with LeetCode as a test fixture into this directory")
+	fs.StringVar(&f.cookieFile, "cookie-file", "", "file holding the LeetCode cookie, instead of $"+config.CookieEnv+" or "+config.DefaultSecretsFile)
+	fs.StringVar(&f.profile, "profile", "", "named profile of the config to use, default app.default_profile")
+}
+
+func (f *scraperFlags) cacheStore() *cache.Store {
+	store := cache.NewStore(f.cacheDir)
+	switch {
+	case f.offline:
+		store.SetMode(cache.ModeOffline)
+	case f.refresh:
+		store.SetMode(cache.ModeRefresh)
+	}
+	return store
+}
+
+// parse parses the command line, loads the config and the discovered company catalog
+// and fills every flag left unset on the command line from the config: flags override
+// the config, which overrides defaults
+func (f *scraperFlags) parse(fs *flag.FlagSet, args []string) error {
+	fs.Parse(args)
+	app, err := f.app()
 	if err != nil {
-		fmt.Printf("Error scraping favorite list: %v\n", err)
-		return
+		return err
+	}
+	catalog, err := registry.LoadCatalog(app.Storage.Companies)
+	if err != nil {
+		return err
+	}
+	if catalog != nil {
+		if err := registry.Use(catalog); err != nil {
+			return fmt.Errorf("%s: %w", app.Storage.Companies, err)
+		}
+	}
+
+	return applyConfiguredFlags(fs, configuredFlags(app))
+}
+
+// conflictingFlags maps a flag onto the one it cannot be combined with; setting
+// either on the command line keeps the config from setting the other
+var conflictingFlags = map[string]string{
+	"exclude-premium": "premium-only",
+	"premium-only":    "exclude-premium",
+	"topic":           "exclude-topic",
+	"exclude-topic":   "topic",
+}
+
+// applyConfiguredFlags sets every flag of fs left unset on the command line to its
+// configured value
+func applyConfiguredFlags(fs *flag.FlagSet, configured map[string]string) error {
+	set := make(map[string]bool)
+	fs.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+		if other, ok := conflictingFlags[fl.Name]; ok {
+			set[other] = true
+		}
+	})
+	command := strings.Fields(fs.Name())[0]
+	for name, value := range configured {
+		if cmd, flagName, scoped := strings.Cut(name, "."); scoped {
+			if cmd != command {
+				continue
+			}
+			name = flagName
+		}
+		if set[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid config value %q for --%s: %w", value, name, err)
+		}
+	}
+	return nil
+}
+
+// configuredFlags maps flags onto their config values, keyed by flag name or by
+// command and flag name for flags whose meaning differs between commands
+func configuredFlags(app *config.Application) map[string]string {
+	return map[string]string{
+		"cache-dir": app.Storage.CacheDir,
+		"data-dir":  app.Storage.DataDir,
+		"catalog":   app.Storage.Catalog,
+		"solved":    app.Storage.Solved,
+
+		"download.chunk": strconv.Itoa(app.Scraper.PageSize),
+		"download.out":   app.Storage.DataDir,
+
+		"pick.list":            app.Picker.List,
+		"pick.count":           strconv.Itoa(app.Picker.Count),
+		"pick.difficulty":      strings.Join(app.Picker.Difficulty, ","),
+		"pick.exclude-premium": strconv.FormatBool(app.Picker.ExcludePremium),
+
+		"export.format":   app.Export.Format,
+		"export.columns":  strings.Join(app.Export.Columns, ","),
+		"export.group-by": app.Export.GroupBy,
+		"export.deck":     app.Export.Deck,
+		"report.out":      app.Export.SiteDir,
+
+		"daily.log":    app.Storage.Daily,
+		"discover.out": app.Storage.Companies,
+	}
+}
+
+// app loads the config file once and applies the selected profile; the default file
+// may be missing, defaults are used then
+func (f *scraperFlags) app() (*config.Application, error) {
+	if f.config != nil {
+		return f.config, nil
+	}
+
+	path := f.configFile
+	if path == defaultConfigFile {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			path = ""
+		}
+	}
+	loaded, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	app, err := loaded.WithProfile(f.profile)
+	if err != nil {
+		return nil, err
+	}
+	f.config = app
+	return app, nil
+}
+
+func (f *scraperFlags) credentials() (*config.Credentials, error) {
+	app, err := f.app()
+	if err != nil {
+		return nil, err
 	}
+	return config.LoadCredentials(app, f.cookieFile)
+}
 
-	totalQuestions := favoriteResponse.Data.FavoriteQuestionList.TotalLength
+func (f *scraperFlags) scraper() (*scrapper.LeetCodeScraper, error) {
+	app, err := f.app()
+	if err != nil {
+		return nil, err
+	}
 
-	var iteration = 1
-	for len(favoriteResponse.Data.FavoriteQuestionList.Questions) < totalQuestions {
-		res, resErr := leetcodeScrapper.GetFavoriteQuestionList(favoriteSlug, iteration*chunkSize, chunkSize)
-		if resErr != nil {
-			fmt.Printf("Error scraping favorite list: %v\n", resErr)
+	opts := []scrapper.Option{
+		scrapper.WithBaseURL(app.Scraper.BaseURL),
+		scrapper.WithHTTPClient(&http.Client{Timeout: app.Scraper.Timeout}),
+		scrapper.WithRequestInterval(app.Scraper.Rate),
+		scrapper.WithRetry(app.Scraper.Retries, app.Scraper.Backoff),
+	}
+	// Public commands never send the session, so it cannot leak into their requests
+	creds := &config.Credentials{}
+	if !f.public {
+		creds, err = f.credentials()
+		if err != nil {
+			return nil, err
 		}
-		favoriteResponse.Data.FavoriteQuestionList.Questions = append(favoriteResponse.Data.FavoriteQuestionList.Questions, res.Data.FavoriteQuestionList.Questions...)
-		iteration++
-		fmt.Println(fmt.Sprintf("%s: fetched %d questions out of %d questions", favoriteSlug, len(favoriteResponse.Data.FavoriteQuestionList.Questions), totalQuestions))
-		time.Sleep(1 * time.Second)
+		if !f.offline {
+			warnSession(app, creds)
+		}
+		opts = append(opts, scrapper.WithCookie(creds.Cookie.Reveal()))
+	}
+	if !f.noCache {
+		store := f.cacheStore()
+		// Profiles signed in as different users share the cache directory
+		store.SetUser(config.ParseCookie(creds.Cookie.Reveal()).LeetCodeSession.Reveal())
+		opts = append(opts, scrapper.WithCache(store))
+	}
+	if f.record != "" {
+		opts = append(opts, scrapper.WithTransport(fixture.NewRecorder(f.record, nil)))
 	}
+	return scrapper.NewLeetCodeScraper(opts...), nil
+}
+
+// sessionWarning is how long before the session expires warnings start
+const sessionWarning = 3 * 24 * time.Hour
 
-	fmt.Printf("Found %d problems in favorite list\n", len(favoriteResponse.Data.FavoriteQuestionList.Questions))
+// warnSession logs when requests will be anonymous or the session is about to expire
+func warnSession(app *config.Application, creds *config.Credentials) {
+	if creds.Cookie == "" {
+		log.Printf("no LeetCode cookie in $%s or %s, requests are anonymous", config.CookieEnvFor(app.Profile()), app.Secrets.File)
+		return
+	}
 
-	// Save favorite list problems
-	if err := utils.SaveToFile(favoriteResponse, fmt.Sprintf("updated_data/%s.json", favoriteSlug)); err != nil {
-		fmt.Printf("Error saving favorite list: %v\n", err)
+	expiry, err := creds.Session().Expiry()
+	if err != nil {
 		return
 	}
+	switch left := time.Until(expiry); {
+	case left <= 0:
+		log.Printf("the LeetCode session from %s expired on %s, copy a fresh cookie from your browser", creds.Source, expiry.Format(time.DateOnly))
+	case left < sessionWarning:
+		log.Printf("the LeetCode session from %s expires in %s", creds.Source, left.Round(time.Hour))
+	}
 }
 
-var leetcodeScrapper *scrapper.LeetCodeScraper
+// repositoryFlags are the flags of commands that only read problem data and
+// therefore run from local files unless told to go live
+type repositoryFlags struct {
+	scraperFlags
+	dataDir string
+	catalog string
+	live    bool
+}
+
+func (f *repositoryFlags) register(fs *flag.FlagSet) {
+	f.scraperFlags.register(fs)
+	fs.StringVar(&f.dataDir, "data-dir", "updated_data", "directory of downloaded favorite lists")
+	fs.StringVar(&f.catalog, "catalog", defaultCatalogFile, "problem catalog written by the mirror command")
+	fs.BoolVar(&f.live, "live", false, "query LeetCode instead of local data")
+}
+
+// repository returns the live scraper with --live, otherwise a local backend that
+// needs neither credentials nor network
+func (f *repositoryFlags) repository() (scrapper.Repository, error) {
+	if f.live {
+		return f.scraper()
+	}
+	details := cache.NewStore(f.cacheDir)
+	details.SetMode(cache.ModeOffline)
+	repo := scrapper.NewLocalRepository(f.dataDir, details)
+
+	catalog, err := scrapper.LoadCatalog(f.catalog)
+	if err != nil {
+		return nil, err
+	}
+	repo.SetCatalog(catalog)
+	return repo, nil
+}
 
-func init() {
-	chunkSize = 10
-	leetcodeScrapper = scrapper.NewLeetCodeScraper()
+// queryFlags expose scrapper.QueryParams as flags
+type queryFlags struct {
+	fs *flag.FlagSet
+}
+
+func (f *queryFlags) register(fs *flag.FlagSet) {
+	f.fs = fs
+	for _, param := range scrapper.QueryParams {
+		if param.Bool {
+			fs.Bool(param.Name, false, param.Usage)
+		} else {
+			fs.String(param.Name, "", param.Usage)
+		}
+	}
+}
+
+// queryOptions returns the query options described by the flags
+func (f *queryFlags) queryOptions() ([]scrapper.QueryOption, error) {
+	return scrapper.ParseQueryOptions(func(name string) string {
+		return f.fs.Lookup(name).Value.String()
+	})
+}
+
+func runPick(args []string) error {
+	fs := flag.NewFlagSet("pick", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	var qf queryFlags
+	qf.register(fs)
+	list := fs.String("list", "amazon-thirty-days", "favorite list to pick from")
+	count := fs.Int("count", 3, "number of questions to pick")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs that are never picked")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	opts, err := qf.queryOptions()
+	if err != nil {
+		return err
+	}
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	solved, err := progress.Load(*solvedFile)
+	if err != nil {
+		return err
+	}
+
+	scrapper.GetRandomQuestion(repo, scrapper.PickOptions{
+		List:     *list,
+		Count:    *count,
+		PageSize: rf.config.Scraper.PageSize,
+		Solved:   solved,
+	}, opts...)
+	return nil
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", name)
+	}
+	fmt.Fprintf(os.Stderr, "\nwithout a command %q is run\n", defaultCommand)
 }
 
 func main() {
-	leetcodeScrapper.GetRandomQuestion()
-	//slugLists := []string{
-	//	//facebook
-	//	"facebook-thirty-days",
-	//	"facebook-three-months",
-	//	"facebook-six-months",
-	//	//google
-	//	"google-thirty-days",
-	//	"google-three-months",
-	//	"google-six-months",
-	//	//amazon
-	//	"amazon-thirty-days",
-	//	"amazon-three-months",
-	//	"amazon-six-months",
-	//	//microsoft
-	//	"microsoft-thirty-days",
-	//	"microsoft-three-months",
-	//	"microsoft-six-months",
-	//	//uber
-	//	"uber-thirty-days",
-	//	"uber-three-months",
-	//	"uber-six-months",
-	//	//apple
-	//	"apple-thirty-days",
-	//	"apple-three-months",
-	//	"apple-six-months",
-	//	//netflix
-	//	"netflix-thirty-days",
-	//	"netflix-three-months",
-	//	"netflix-six-months",
-	//	//bloomberg
-	//	"bloomberg-thirty-days",
-	//	"bloomberg-three-months",
-	//	"bloomberg-six-months",
-	////tiktok
-	//"tiktok-thirty-days",
-	//"tiktok-three-months",
-	//"tiktok-six-months",
-	//}
-	//downloadCompanyProblems(slugLists[0])
-	//for _, slug := range slugLists {
-	//	downloadCompanyProblems(slug)
-	//	fmt.Printf("Scraping %s completed\n", slug)
-	//	time.Sleep(2 * time.Second)
-	//}
-
-	//// Example 2: Scrape all problems (first batch)
-	//fmt.Println("Scraping all problems (first 50)...")
-	//allProblems, err := scraper.GetAllProblems(0, 50)
-	//if err != nil {
-	//	fmt.Printf("Error scraping all problems: %v\n", err)
-	//	return
-	//}
-	//
-	//fmt.Printf("Found %d problems\n", len(allProblems))
-	//
-	//// Save all problems
-	//if err := utils.SaveToFile(allProblems, "data/all_problems_batch_1.json"); err != nil {
-	//	fmt.Printf("Error saving all problems: %v\n", err)
-	//	return
-	//}
-	//
-	//// Example 3: Get detailed information for specific problems
-	//fmt.Println("Getting detailed information for first few problems...")
-	//detailsDir := "data/problem_details"
-	//
-	//for i, problem := range allProblems[:5] { // Get details for first 5 problems
-	//	if problem.PaidOnly {
-	//		fmt.Printf("Skipping paid problem: %s\n", problem.Title)
-	//		continue
-	//	}
-	//
-	//	fmt.Printf("Getting details for: %s\n", problem.Title)
-	//	detail, err := scraper.GetProblemDetail(problem.TitleSlug)
-	//	if err != nil {
-	//		fmt.Printf("Error getting details for %s: %v\n", problem.Title, err)
-	//		continue
-	//	}
-	//
-	//	filename := fmt.Sprintf("%s/%d_%s.json", detailsDir, i+1,
-	//		strings.ReplaceAll(problem.TitleSlug, "-", "_"))
-	//
-	//	if err := utils.SaveToFile(detail, filename); err != nil {
-	//		fmt.Printf("Error saving details for %s: %v\n", problem.Title, err)
-	//		continue
-	//	}
-	//
-	//	// Be respectful to the server
-	//	time.Sleep(1 * time.Second)
-	//}
-
-	//fmt.Println("Scraping completed! Check the 'data' directory for results.")
+	name, args := defaultCommand, os.Args[1:]
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	log.SetOutput(config.RedactingWriter(os.Stderr))
+	if err := cmd(args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, config.Redact(err.Error()))
+		os.Exit(1)
+	}
 }
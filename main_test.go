@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestConfiguredFlagsYieldToConflictingFlags(t *testing.T) {
+	configured := map[string]string{
+		"pick.exclude-premium": "true",
+		"pick.difficulty":      "MEDIUM",
+		"export.format":        "csv",
+	}
+	parse := func(args ...string) *queryFlags {
+		t.Helper()
+		fs := flag.NewFlagSet("pick", flag.ContinueOnError)
+		var qf queryFlags
+		qf.register(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyConfiguredFlags(fs, configured); err != nil {
+			t.Fatal(err)
+		}
+		return &qf
+	}
+
+	qf := parse()
+	if got := qf.fs.Lookup("exclude-premium").Value.String(); got != "true" {
+		t.Errorf("the configured exclude-premium must apply without flags, got %s", got)
+	}
+
+	qf = parse("--premium-only")
+	if got := qf.fs.Lookup("exclude-premium").Value.String(); got != "false" {
+		t.Errorf("--premium-only must keep the configured exclude-premium out, got %s", got)
+	}
+	if got := qf.fs.Lookup("difficulty").Value.String(); got != "MEDIUM" {
+		t.Errorf("unrelated configured flags still apply, got %q", got)
+	}
+	if _, err := qf.queryOptions(); err != nil {
+		t.Errorf("--premium-only with the default config must be accepted, got %v", err)
+	}
+}
+
+func TestCheckUsernames(t *testing.T) {
+	if err := checkUsernames([]string{"alice", "Bob_42", "lee-215"}); err != nil {
+		t.Errorf("plain usernames must be accepted, got %v", err)
+	}
+	for _, username := range []string{"", "..", "../../etc/cron.d/x", "a/b", `a\b`, "alice bob"} {
+		if err := checkUsernames([]string{"alice", username}); err == nil {
+			t.Errorf("%q must be rejected", username)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/scrapper"
+)
+
+func runMirror(args []string) error {
+	fs := flag.NewFlagSet("mirror", flag.ExitOnError)
+	var sf scraperFlags
+	sf.register(fs)
+	catalogFile := fs.String("catalog", defaultCatalogFile, "file the problem catalog is stored in")
+	pageSize := fs.Int("page", 100, "number of problems requested per page")
+	fullRefresh := fs.Bool("refresh-stats", false, "page through the whole catalog to refresh acceptance rates and statuses")
+	if err := sf.parse(fs, args); err != nil {
+		return err
+	}
+	if sf.offline {
+		return fmt.Errorf("mirror fetches the catalog from LeetCode and cannot run offline")
+	}
+
+	catalog, err := scrapper.LoadCatalog(*catalogFile)
+	if err != nil {
+		return err
+	}
+	known := len(catalog.Questions)
+
+	scraper, err := sf.scraper()
+	if err != nil {
+		return err
+	}
+	result, err := catalog.Mirror(scraper, *pageSize, *fullRefresh, func(fetched, total int) {
+		fmt.Printf("catalog: fetched %d problems out of %d\n", fetched, total)
+	})
+	if err != nil {
+		// Keep whatever was fetched before the failure
+		if saveErr := catalog.Save(*catalogFile); saveErr != nil {
+			fmt.Printf("Error saving catalog: %v\n", saveErr)
+		}
+		return err
+	}
+
+	if err := catalog.Save(*catalogFile); err != nil {
+		return fmt.Errorf("failed to save catalog: %w", err)
+	}
+
+	fmt.Printf("Catalog has %d problems (%d before, highest ID %d), %d acceptance rates refreshed\n", len(catalog.Questions), known, catalog.MaxFrontendID(), result.Refreshed)
+	if len(result.Added) > 0 {
+		fmt.Printf("\nNewly published questions:\n")
+		for _, problem := range scrapper.ProblemsFromQuestions(result.Added, scrapper.FromCatalog, "") {
+			fmt.Printf("%d. %s [%s] %s\n", problem.FrontendID, problem.Title, problem.Difficulty, problem.URL())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package progress
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Daily is the log of completed daily challenges kept in a plain text file, one
+// "date title-slug" line per day
+type Daily struct {
+	path string
+	days map[string]string
+	mu   sync.RWMutex
+}
+
+// LoadDaily reads the daily challenge log; a missing file means no challenge was completed yet
+func LoadDaily(path string) (*Daily, error) {
+	daily := &Daily{path: path, days: make(map[string]string)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return daily, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		date, titleSlug, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			// Skip empty and malformed lines
+			continue
+		}
+		daily.days[date] = strings.TrimSpace(titleSlug)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return daily, nil
+}
+
+// Path returns the file the log is stored in
+func (d *Daily) Path() string {
+	return d.path
+}
+
+// Done reports whether the challenge of a date, e.g. 2024-05-01, was completed
+func (d *Daily) Done(date string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.days[date]
+	return ok
+}
+
+// Record marks the challenge of a date as completed, reporting whether it was new
+func (d *Daily) Record(date, titleSlug string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.days[date]; ok {
+		return false
+	}
+	d.days[date] = titleSlug
+	return true
+}
+
+// Streak returns the number of consecutive days completed up to today, which counts
+// from yesterday while today's challenge is still open, and the longest streak ever
+func (d *Daily) Streak(today string) (current, longest int) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	dates := d.sortedDates()
+	run := 0
+	var previous time.Time
+	for _, date := range dates {
+		day, _ := time.Parse(time.DateOnly, date)
+		if run > 0 && day.Sub(previous) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		previous = day
+	}
+
+	day, err := time.Parse(time.DateOnly, today)
+	if err != nil {
+		return 0, longest
+	}
+	if _, ok := d.days[today]; !ok {
+		day = day.AddDate(0, 0, -1)
+	}
+	for {
+		if _, ok := d.days[day.Format(time.DateOnly)]; !ok {
+			break
+		}
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+func (d *Daily) sortedDates() []string {
+	dates := make([]string, 0, len(d.days))
+	for date := range d.days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// Save writes the log back to its file, oldest day first
+func (d *Daily) Save() error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(d.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var b strings.Builder
+	for _, date := range d.sortedDates() {
+		fmt.Fprintf(&b, "%s %s\n", date, d.days[date])
+	}
+	if err := os.WriteFile(d.path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package progress
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDailyStreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.txt")
+	daily, err := LoadDaily(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, date := range []string{"2024-04-01", "2024-04-02", "2024-04-03", "2024-04-10", "2024-04-11"} {
+		daily.Record(date, "two-sum")
+	}
+	if daily.Record("2024-04-11", "lru-cache") {
+		t.Error("a day is recorded once")
+	}
+	if err := daily.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	daily, err = LoadDaily(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for today, want := range map[string]int{
+		"2024-04-11": 2, // completed today
+		"2024-04-12": 2, // today still open
+		"2024-04-13": 0, // missed yesterday
+	} {
+		current, longest := daily.Streak(today)
+		if current != want || longest != 3 {
+			t.Errorf("%s: streak %d, longest %d", today, current, longest)
+		}
+	}
+}
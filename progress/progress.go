@@ -0,0 +1,105 @@
+package progress
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Solved is the set of solved title slugs kept in a plain text file, one slug per line
+type Solved struct {
+	path  string
+	slugs map[string]bool
+	order []string
+	mu    sync.RWMutex
+}
+
+// Load reads solved title slugs from a text file; a missing file means nothing is solved yet
+func Load(path string) (*Solved, error) {
+	solved := &Solved{path: path, slugs: make(map[string]bool)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return solved, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		solved.add(strings.TrimSpace(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return solved, nil
+}
+
+// Path returns the file the solved slugs are stored in
+func (s *Solved) Path() string {
+	return s.path
+}
+
+// Has reports whether a title slug is solved
+func (s *Solved) Has(titleSlug string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.slugs[titleSlug]
+}
+
+// Len returns the number of solved questions
+func (s *Solved) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.order)
+}
+
+// Slugs returns the solved title slugs in the order they were recorded
+func (s *Solved) Slugs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.order...)
+}
+
+// Add marks a title slug as solved, reporting whether it was new
+func (s *Solved) Add(titleSlug string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(strings.TrimSpace(titleSlug))
+}
+
+func (s *Solved) add(titleSlug string) bool {
+	// Skip empty lines and duplicates
+	if titleSlug == "" || s.slugs[titleSlug] {
+		return false
+	}
+	s.slugs[titleSlug] = true
+	s.order = append(s.order, titleSlug)
+	return true
+}
+
+// Save writes the solved title slugs back to their file
+func (s *Solved) Save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var b strings.Builder
+	for _, slug := range s.order {
+		b.WriteString(slug)
+		b.WriteString("\n")
+	}
+	if err := os.WriteFile(s.path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
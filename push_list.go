@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+)
+
+// pickSet returns up to count unsolved problems of the lists in order, without duplicates
+func pickSet(repo scrapper.Repository, slugs []string, count, pageSize int, solved *progress.Solved, opts ...scrapper.QueryOption) ([]scrapper.Problem, error) {
+	seen := make(map[string]bool)
+	var picked []scrapper.Problem
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, pageSize, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, problem := range response.Problems(slug) {
+			if seen[problem.TitleSlug] || problem.Status == "SOLVED" || solved.Has(problem.TitleSlug) {
+				continue
+			}
+			seen[problem.TitleSlug] = true
+			picked = append(picked, problem)
+			if len(picked) == count {
+				return picked, nil
+			}
+		}
+	}
+	return picked, nil
+}
+
+func runPushList(args []string) error {
+	fs := flag.NewFlagSet("push-list", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	var qf queryFlags
+	qf.register(fs)
+	name := fs.String("name", "", "name of the LeetCode list to push to, created when missing")
+	description := fs.String("description", "", "description of a created list")
+	public := fs.Bool("public", false, "make a created list public")
+	count := fs.Int("count", 50, "number of questions pushed")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs that are never pushed")
+	replace := fs.Bool("replace", false, "remove questions of the list that are not in the pushed set")
+	dryRun := fs.Bool("dry-run", false, "print the questions instead of pushing them")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("missing --name of the list to push to")
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no favorite slugs given to pick from, e.g. google-six-months")
+	}
+
+	opts, err := qf.queryOptions()
+	if err != nil {
+		return err
+	}
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	solved, err := progress.Load(*solvedFile)
+	if err != nil {
+		return err
+	}
+	problems, err := pickSet(repo, fs.Args(), *count, rf.config.Scraper.PageSize, solved, opts...)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		return fmt.Errorf("no unsolved questions match, nothing to push")
+	}
+
+	if *dryRun {
+		for i, problem := range problems {
+			fmt.Printf("%d. %s (%s)\n", i+1, problem.TitleSlug, problem.Difficulty)
+		}
+		fmt.Printf("Would push %d questions to %q\n", len(problems), *name)
+		return nil
+	}
+
+	scraper, err := rf.scraper()
+	if err != nil {
+		return err
+	}
+	lists, err := scraper.GetMyFavoriteLists()
+	if err != nil {
+		return err
+	}
+
+	var favoriteSlug string
+	for _, list := range lists {
+		if list.Name == *name {
+			favoriteSlug = list.Slug
+			break
+		}
+	}
+	existing := make(map[string]bool)
+	if favoriteSlug == "" {
+		if favoriteSlug, err = scraper.CreateFavoriteList(*name, *description, *public); err != nil {
+			return err
+		}
+		fmt.Printf("Created list %q\n", *name)
+	} else {
+		response, err := scrapper.FetchFavoriteList(scraper, favoriteSlug, rf.config.Scraper.PageSize, scrapper.Uncached())
+		if err != nil {
+			return err
+		}
+		for _, q := range response.Data.FavoriteQuestionList.Questions {
+			existing[q.TitleSlug] = true
+		}
+	}
+
+	pushed := make(map[string]bool)
+	var missing []string
+	for _, problem := range problems {
+		pushed[problem.TitleSlug] = true
+		if !existing[problem.TitleSlug] {
+			missing = append(missing, problem.TitleSlug)
+		}
+	}
+	if err := scraper.AddQuestionsToFavorite(favoriteSlug, missing...); err != nil {
+		return err
+	}
+
+	removed := 0
+	if *replace {
+		for titleSlug := range existing {
+			if pushed[titleSlug] {
+				continue
+			}
+			if err := scraper.RemoveQuestionFromFavorite(favoriteSlug, titleSlug); err != nil {
+				return err
+			}
+			removed++
+		}
+	}
+
+	fmt.Printf("Pushed %d questions to %q: %d added, %d removed\n", len(problems), *name, len(missing), removed)
+	fmt.Printf("%sproblem-list/%s/\n", rf.config.SiteURL(), favoriteSlug)
+	return nil
+}
@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// List is a company favorite list found by discovery
+type List struct {
+	Slug      string `json:"slug"`
+	Period    string `json:"period"`
+	Questions int    `json:"questions"`
+}
+
+// Company is a company tag and the favorite lists published for it
+type Company struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// QuestionCount is the number of questions tagged with the company
+	QuestionCount int    `json:"questionCount"`
+	Lists         []List `json:"lists"`
+}
+
+// Catalog is the result of discovering the company lists LeetCode publishes
+type Catalog struct {
+	Discovered time.Time `json:"discovered"`
+	Companies  []Company `json:"companies"`
+}
+
+// LoadCatalog reads a catalog saved by Save; a missing file returns nil, the
+// registry then keeps its built in companies
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read company catalog: %w", err)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &catalog, nil
+}
+
+// Save writes the catalog as JSON
+func (c *Catalog) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal company catalog: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write company catalog: %w", err)
+	}
+	return nil
+}
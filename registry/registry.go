@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Companies are the companies whose favorite lists are tracked
+var Companies = []string{
+	"facebook",
+	"google",
+	"amazon",
+	"microsoft",
+	"uber",
+	"apple",
+	"netflix",
+	"bloomberg",
+	"tiktok",
+}
+
+// Periods are the time windows LeetCode publishes for every company list
+var Periods = []string{
+	"thirty-days",
+	"three-months",
+	"six-months",
+}
+
+// Windows are every time window LeetCode may publish a company list for, the
+// tracked Periods included; longer suffixes come first so Split matches them
+var Windows = []string{
+	"more-than-six-months",
+	"thirty-days",
+	"three-months",
+	"six-months",
+	"all",
+}
+
+var (
+	mu sync.RWMutex
+	// discovered holds the list slugs of every company once a catalog is used
+	discovered map[string][]string
+)
+
+// Use makes the registry track the companies of a discovered catalog, most asked
+// first, and only the lists the catalog found. A catalog without any list is
+// refused: tracking it would silently download nothing.
+func Use(catalog *Catalog) error {
+	lists := make(map[string][]string)
+	var companies []string
+	for _, company := range catalog.Companies {
+		if len(company.Lists) == 0 {
+			continue
+		}
+		companies = append(companies, company.Slug)
+		for _, list := range company.Lists {
+			lists[company.Slug] = append(lists[company.Slug], list.Slug)
+		}
+	}
+	if len(companies) == 0 {
+		return fmt.Errorf("the company catalog holds no lists, run discover again")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	Companies = companies
+	discovered = lists
+	return nil
+}
+
+// Slugs returns the favorite slug of every tracked company and period
+func Slugs() []string {
+	return SlugsOf(nil)
+}
+
+// SlugsOf returns the favorite slug of every period of the given companies, of every
+// tracked company when none are given. Once a catalog is used, these are the lists
+// it found, whatever their window.
+func SlugsOf(companies []string) []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if len(companies) == 0 {
+		companies = Companies
+	}
+	var slugs []string
+	for _, company := range companies {
+		if discovered != nil {
+			slugs = append(slugs, discovered[company]...)
+			continue
+		}
+		for _, period := range Periods {
+			slugs = append(slugs, Slug(company, period))
+		}
+	}
+	return slugs
+}
+
+// Slug returns the favorite slug of a company list, e.g. google-six-months
+func Slug(company, period string) string {
+	return fmt.Sprintf("%s-%s", company, period)
+}
+
+// Split returns the company and period of a favorite slug; slugs without a known
+// period are returned whole as the company
+func Split(favoriteSlug string) (company, period string) {
+	for _, period := range Windows {
+		if company := strings.TrimSuffix(favoriteSlug, "-"+period); company != favoriteSlug {
+			return company, period
+		}
+	}
+	return favoriteSlug, ""
+}
+
+// CompanyOf returns the company a favorite slug belongs to, e.g. google for google-six-months
+func CompanyOf(favoriteSlug string) string {
+	company, _ := Split(favoriteSlug)
+	return company
+}
@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for slug, want := range map[string][2]string{
+		"google-six-months":           {"google", "six-months"},
+		"google-more-than-six-months": {"google", "more-than-six-months"},
+		"jane-street-all":             {"jane-street", "all"},
+		"my-list":                     {"my-list", ""},
+	} {
+		if company, period := Split(slug); company != want[0] || period != want[1] {
+			t.Errorf("Split(%q) = %q, %q", slug, company, period)
+		}
+	}
+}
+
+func TestUseCatalog(t *testing.T) {
+	companies := Companies
+	t.Cleanup(func() {
+		Companies, discovered = companies, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "companies.json")
+	if catalog, err := LoadCatalog(path); err != nil || catalog != nil {
+		t.Fatalf("a missing catalog is not an error, got %v, %v", catalog, err)
+	}
+	saved := &Catalog{Companies: []Company{
+		{Slug: "stripe", Lists: []List{{Slug: "stripe-six-months", Period: "six-months"}, {Slug: "stripe-all", Period: "all"}}},
+		{Slug: "nobody"},
+	}}
+	if err := saved.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Use(catalog); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(Companies, []string{"stripe"}) {
+		t.Errorf("companies without lists must not be tracked, got %v", Companies)
+	}
+	if got := Slugs(); !reflect.DeepEqual(got, []string{"stripe-six-months", "stripe-all"}) {
+		t.Errorf("every discovered list must be tracked, whatever its window, got %v", got)
+	}
+	if got := SlugsOf([]string{"google"}); len(got) != 0 {
+		t.Errorf("undiscovered lists must be left out, got %v", got)
+	}
+}
+
+func TestUseEmptyCatalog(t *testing.T) {
+	companies := Companies
+	t.Cleanup(func() {
+		Companies, discovered = companies, nil
+	})
+
+	if err := Use(&Catalog{Companies: []Company{{Slug: "nobody"}}}); err == nil {
+		t.Fatal("a catalog without lists must be refused")
+	}
+	if !reflect.DeepEqual(Companies, companies) || len(Slugs()) != len(companies)*len(Periods) {
+		t.Errorf("a refused catalog must leave the built in companies tracked, got %v", Companies)
+	}
+}
@@ -0,0 +1,51 @@
+(function () {
+  var difficultyRank = { EASY: 0, MEDIUM: 1, HARD: 2 };
+
+  function cellValue(row, index, type) {
+    var text = row.cells[index].textContent.trim();
+    if (type === "number") return parseFloat(text) || 0;
+    if (type === "difficulty") return difficultyRank[text] || 0;
+    return text.toLowerCase();
+  }
+
+  document.querySelectorAll("table.sortable").forEach(function (table) {
+    var headers = table.tHead.rows[0].cells;
+    Array.prototype.forEach.call(headers, function (th, index) {
+      th.addEventListener("click", function () {
+        var ascending = !th.classList.contains("asc");
+        Array.prototype.forEach.call(headers, function (h) { h.classList.remove("asc", "desc"); });
+        th.classList.add(ascending ? "asc" : "desc");
+
+        var body = table.tBodies[0];
+        var rows = Array.prototype.slice.call(body.rows);
+        var type = th.dataset.type;
+        rows.sort(function (a, b) {
+          var x = cellValue(a, index, type), y = cellValue(b, index, type);
+          if (x === y) return 0;
+          return (x < y ? -1 : 1) * (ascending ? 1 : -1);
+        });
+        rows.forEach(function (row) { body.appendChild(row); });
+      });
+    });
+  });
+
+  var controls = document.querySelectorAll("[data-filter]");
+  function applyFilters() {
+    var text = "", difficulty = "", unsolved = false;
+    controls.forEach(function (control) {
+      if (control.dataset.filter === "text") text = control.value.toLowerCase();
+      if (control.dataset.filter === "difficulty") difficulty = control.value;
+      if (control.dataset.filter === "unsolved") unsolved = control.checked;
+    });
+    document.querySelectorAll("table.problems tbody tr").forEach(function (row) {
+      var visible = (!text || row.textContent.toLowerCase().indexOf(text) >= 0) &&
+        (!difficulty || row.dataset.difficulty === difficulty) &&
+        (!unsolved || row.dataset.solved !== "true");
+      row.style.display = visible ? "" : "none";
+    });
+  }
+  controls.forEach(function (control) {
+    control.addEventListener("input", applyFilters);
+    control.addEventListener("change", applyFilters);
+  });
+})();
@@ -0,0 +1,326 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html assets/*
+var files embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"percent": func(f float64) string { return fmt.Sprintf("%.0f%%", f) },
+	"lower":   strings.ToLower,
+}).ParseFS(files, "templates/*.html"))
+
+// List is one downloaded favorite list, e.g. the six month list of a company
+type List struct {
+	Slug     string
+	Company  string
+	Period   string
+	Problems []scrapper.Problem
+}
+
+// Dataset is everything a report is rendered from
+type Dataset struct {
+	Lists  []List
+	Solved *progress.Solved
+}
+
+type bar struct {
+	Label   string
+	Count   int
+	Percent float64
+}
+
+type row struct {
+	scrapper.Problem
+	Solved     bool
+	Topics     string
+	Acceptance float64
+}
+
+type progressBar struct {
+	Solved  int
+	Total   int
+	Percent float64
+}
+
+type listSummary struct {
+	List
+	Progress progressBar
+}
+
+type companySummary struct {
+	Name     string
+	Lists    []listSummary
+	Progress progressBar
+}
+
+type overlapCell struct {
+	Shared  int
+	Percent float64
+}
+
+type overlapRow struct {
+	Company string
+	Cells   []overlapCell
+}
+
+type page struct {
+	Title     string
+	Generated string
+	CSS       template.CSS
+	JS        template.JS
+}
+
+type indexPage struct {
+	page
+	Companies []companySummary
+	Progress  progressBar
+	Overlap   []overlapRow
+	Names     []string
+}
+
+type listPage struct {
+	page
+	List       List
+	Rows       []row
+	Progress   progressBar
+	Difficulty []bar
+	Topics     []bar
+}
+
+// Generate renders a self-contained static site into dir: index.html with overall
+// progress and the company overlap matrix, and one page per list. Styles and scripts
+// are inlined so the pages work when opened from a file:// URL.
+func Generate(dir string, data Dataset) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	css, err := files.ReadFile("assets/style.css")
+	if err != nil {
+		return err
+	}
+	js, err := files.ReadFile("assets/table.js")
+	if err != nil {
+		return err
+	}
+	base := page{
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		CSS:       template.CSS(css),
+		JS:        template.JS(js),
+	}
+
+	for _, list := range data.Lists {
+		p := listPage{page: base, List: list}
+		p.Title = list.Slug
+		p.Rows, p.Progress = rows(list.Problems, data.Solved)
+		p.Difficulty = difficultyBars(list.Problems)
+		p.Topics = topicBars(list.Problems, 15)
+
+		if err := render(filepath.Join(dir, list.Slug+".html"), "list.html", p); err != nil {
+			return err
+		}
+	}
+
+	index := indexPage{page: base}
+	index.Title = "LeetCode company lists"
+	index.Companies, index.Progress = companies(data)
+	index.Names, index.Overlap = overlap(data.Lists)
+	return render(filepath.Join(dir, "index.html"), "index.html", index)
+}
+
+func render(filename, name string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", filename, err)
+	}
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+func isSolved(p scrapper.Problem, solved *progress.Solved) bool {
+	return p.Status == "SOLVED" || (solved != nil && solved.Has(p.TitleSlug))
+}
+
+func rows(problems []scrapper.Problem, solved *progress.Solved) ([]row, progressBar) {
+	out := make([]row, 0, len(problems))
+	done := 0
+	for _, problem := range problems {
+		names := make([]string, 0, len(problem.TopicTags))
+		for _, tag := range problem.TopicTags {
+			names = append(names, tag.Name)
+		}
+		r := row{
+			Problem:    problem,
+			Solved:     isSolved(problem, solved),
+			Topics:     strings.Join(names, ", "),
+			Acceptance: problem.AcceptancePercent(),
+		}
+		if r.Solved {
+			done++
+		}
+		out = append(out, r)
+	}
+	return out, newProgress(done, len(problems))
+}
+
+func newProgress(solved, total int) progressBar {
+	p := progressBar{Solved: solved, Total: total}
+	if total > 0 {
+		p.Percent = float64(solved) * 100 / float64(total)
+	}
+	return p
+}
+
+func difficultyBars(problems []scrapper.Problem) []bar {
+	counts := make(map[string]int)
+	for _, problem := range problems {
+		counts[problem.Difficulty]++
+	}
+
+	var bars []bar
+	for _, difficulty := range []string{"EASY", "MEDIUM", "HARD"} {
+		bars = append(bars, newBar(difficulty, counts[difficulty], len(problems)))
+	}
+	return bars
+}
+
+// topicBars returns the most common topics, at most limit of them
+func topicBars(problems []scrapper.Problem, limit int) []bar {
+	counts := make(map[string]int)
+	for _, problem := range problems {
+		for _, tag := range problem.TopicTags {
+			counts[tag.Name]++
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > limit {
+		names = names[:limit]
+	}
+
+	bars := make([]bar, 0, len(names))
+	for _, name := range names {
+		bars = append(bars, newBar(name, counts[name], len(problems)))
+	}
+	return bars
+}
+
+func newBar(label string, count, total int) bar {
+	b := bar{Label: label, Count: count}
+	if total > 0 {
+		b.Percent = float64(count) * 100 / float64(total)
+	}
+	return b
+}
+
+// companies groups lists by company and computes progress over the distinct
+// problems of each company and of the whole dataset
+func companies(data Dataset) ([]companySummary, progressBar) {
+	byName := make(map[string]*companySummary)
+	unique := make(map[string]map[string]bool)
+	all := make(map[string]bool)
+	allSolved := 0
+
+	var order []string
+	for _, list := range data.Lists {
+		summary, ok := byName[list.Company]
+		if !ok {
+			summary = &companySummary{Name: list.Company}
+			byName[list.Company] = summary
+			unique[list.Company] = make(map[string]bool)
+			order = append(order, list.Company)
+		}
+
+		_, listProgress := rows(list.Problems, data.Solved)
+		summary.Lists = append(summary.Lists, listSummary{List: list, Progress: listProgress})
+
+		for _, problem := range list.Problems {
+			unique[list.Company][problem.TitleSlug] = isSolved(problem, data.Solved)
+			if !all[problem.TitleSlug] {
+				all[problem.TitleSlug] = true
+				if isSolved(problem, data.Solved) {
+					allSolved++
+				}
+			}
+		}
+	}
+
+	sort.Strings(order)
+	summaries := make([]companySummary, 0, len(order))
+	for _, name := range order {
+		summary := byName[name]
+		solved := 0
+		for _, done := range unique[name] {
+			if done {
+				solved++
+			}
+		}
+		summary.Progress = newProgress(solved, len(unique[name]))
+		summaries = append(summaries, *summary)
+	}
+	return summaries, newProgress(allSolved, len(all))
+}
+
+// overlap counts the problems shared by every pair of companies across all their
+// lists; the percentage is relative to the company of the row
+func overlap(lists []List) ([]string, []overlapRow) {
+	sets := make(map[string]map[string]bool)
+	for _, list := range lists {
+		if sets[list.Company] == nil {
+			sets[list.Company] = make(map[string]bool)
+		}
+		for _, problem := range list.Problems {
+			sets[list.Company][problem.TitleSlug] = true
+		}
+	}
+
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matrix := make([]overlapRow, 0, len(names))
+	for _, a := range names {
+		r := overlapRow{Company: a}
+		for _, b := range names {
+			shared := 0
+			for slug := range sets[a] {
+				if sets[b][slug] {
+					shared++
+				}
+			}
+			cell := overlapCell{Shared: shared}
+			if len(sets[a]) > 0 {
+				cell.Percent = float64(shared) * 100 / float64(len(sets[a]))
+			}
+			r.Cells = append(r.Cells, cell)
+		}
+		matrix = append(matrix, r)
+	}
+	return names, matrix
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/report"
+	"leetcode-scrapper/scrapper"
+	"path/filepath"
+	"slices"
+)
+
+// listSlugs returns the favorite slugs a command works on: the ones given, every
+// list saved locally, or every tracked company list when querying LeetCode. Without
+// arguments only lists of companies are returned, when any are given.
+func listSlugs(repo scrapper.Repository, args []string, companies []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	local, ok := repo.(*scrapper.LocalRepository)
+	if !ok {
+		return registry.SlugsOf(companies), nil
+	}
+
+	slugs, err := local.FavoriteSlugs()
+	if err != nil || len(companies) == 0 {
+		return slugs, err
+	}
+	var kept []string
+	for _, slug := range slugs {
+		if slices.Contains(companies, registry.CompanyOf(slug)) {
+			kept = append(kept, slug)
+		}
+	}
+	return kept, nil
+}
+
+func runReport(args []string) error {
+	if len(args) == 0 || args[0] != "html" {
+		return fmt.Errorf("missing subcommand, expected html")
+	}
+
+	fs := flag.NewFlagSet("report html", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	outDir := fs.String("out", "site", "directory the site is written to")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs shown as progress")
+	if err := rf.parse(fs, args[1:]); err != nil {
+		return err
+	}
+
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	slugs, err := listSlugs(repo, fs.Args(), rf.config.Companies())
+	if err != nil {
+		return err
+	}
+	solved, err := progress.Load(*solvedFile)
+	if err != nil {
+		return err
+	}
+
+	data := report.Dataset{Solved: solved}
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, rf.config.Scraper.PageSize)
+		if err != nil {
+			return err
+		}
+		company, period := registry.Split(slug)
+		data.Lists = append(data.Lists, report.List{
+			Slug:     slug,
+			Company:  company,
+			Period:   period,
+			Problems: response.Problems(slug),
+		})
+	}
+
+	if err := report.Generate(*outDir, data); err != nil {
+		return err
+	}
+
+	index, _ := filepath.Abs(filepath.Join(*outDir, "index.html"))
+	fmt.Printf("Report of %d lists written to file://%s\n", len(data.Lists), index)
+	return nil
+}
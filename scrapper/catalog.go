@@ -0,0 +1,225 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"leetcode-scrapper/utils"
+	"os"
+	"sort"
+	"time"
+)
+
+// CatalogVersion is the format of catalogs written by Save. Version 1 stores acceptance
+// rates as fractions; unversioned catalogs may hold the percentages the first mirror
+// stored and are migrated by LoadCatalog.
+const CatalogVersion = 1
+
+// Catalog is a local mirror of the whole LeetCode problem set
+type Catalog struct {
+	Version   int        `json:"version"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	Questions []Question `json:"questions"`
+
+	bySlug       map[string]int
+	byFrontendID map[int]int
+}
+
+// ProblemsetSource pages through the problem catalog, see LeetCodeScraper.GetProblemsetPage
+type ProblemsetSource interface {
+	GetProblemsetPage(skip, limit int, newestFirst bool) ([]Question, int, error)
+}
+
+// LoadCatalog reads a catalog from disk; a missing file yields an empty catalog
+func LoadCatalog(filename string) (*Catalog, error) {
+	catalog := &Catalog{}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			catalog.index()
+			return catalog, nil
+		}
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	if err := json.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog %s: %w", filename, err)
+	}
+	if catalog.Version > CatalogVersion {
+		return nil, fmt.Errorf("catalog %s has version %d, this build reads up to %d", filename, catalog.Version, CatalogVersion)
+	}
+	catalog.migrate()
+	catalog.index()
+	return catalog, nil
+}
+
+// migrate converts an unversioned catalog to the current version. Acceptance rates
+// above 1 can only be percentages, a catalog holding any was stored by the first
+// mirror and all of its rates are converted.
+func (c *Catalog) migrate() {
+	if c.Version == 0 {
+		percentages := false
+		for _, question := range c.Questions {
+			if question.AcRate > 1 {
+				percentages = true
+				break
+			}
+		}
+		if percentages {
+			for i := range c.Questions {
+				c.Questions[i].AcRate = acRateFromPercent(c.Questions[i].AcRate)
+			}
+		}
+	}
+	c.Version = CatalogVersion
+}
+
+// Save writes the catalog to disk ordered by frontend ID
+func (c *Catalog) Save(filename string) error {
+	c.Version = CatalogVersion
+	sort.Slice(c.Questions, func(i, j int) bool {
+		return frontendID(c.Questions[i]) < frontendID(c.Questions[j])
+	})
+	c.index()
+	return utils.SaveToFile(c, filename)
+}
+
+func (c *Catalog) index() {
+	c.bySlug = make(map[string]int, len(c.Questions))
+	c.byFrontendID = make(map[int]int, len(c.Questions))
+	for i, question := range c.Questions {
+		c.bySlug[question.TitleSlug] = i
+		c.byFrontendID[frontendID(question)] = i
+	}
+}
+
+// BySlug looks a question up by its title slug
+func (c *Catalog) BySlug(titleSlug string) (Question, bool) {
+	i, ok := c.bySlug[titleSlug]
+	if !ok {
+		return Question{}, false
+	}
+	return c.Questions[i], true
+}
+
+// ByFrontendID looks a question up by the number shown on the website
+func (c *Catalog) ByFrontendID(id int) (Question, bool) {
+	i, ok := c.byFrontendID[id]
+	if !ok {
+		return Question{}, false
+	}
+	return c.Questions[i], true
+}
+
+// MaxFrontendID returns the highest frontend ID known to the catalog
+func (c *Catalog) MaxFrontendID() int {
+	max := 0
+	for id := range c.byFrontendID {
+		if id > max {
+			max = id
+		}
+	}
+	return max
+}
+
+// Upsert adds a question or refreshes the stored copy, reporting whether it was new
+func (c *Catalog) Upsert(question Question) bool {
+	if i, ok := c.bySlug[question.TitleSlug]; ok {
+		c.Questions[i] = question
+		return false
+	}
+	c.Questions = append(c.Questions, question)
+	c.bySlug[question.TitleSlug] = len(c.Questions) - 1
+	c.byFrontendID[frontendID(question)] = len(c.Questions) - 1
+	return true
+}
+
+// MirrorResult reports what a Mirror run changed
+type MirrorResult struct {
+	// Added holds the newly published questions, empty on the first run
+	Added []Question
+	// Refreshed counts the known questions whose acceptance rate changed
+	Refreshed int
+}
+
+// Mirror brings the catalog up to date. An empty catalog or a full refresh pages
+// through every problem; otherwise only the newest problems are fetched until a
+// known frontend ID is reached. Either way every fetched question replaces its
+// stored copy, so acceptance rates and statuses of the fetched pages are refreshed.
+// progress, when not nil, is called after every page with the number of problems
+// fetched so far and the size of the problemset.
+func (c *Catalog) Mirror(source ProblemsetSource, pageSize int, fullRefresh bool, progress func(fetched, total int)) (*MirrorResult, error) {
+	if progress == nil {
+		progress = func(int, int) {}
+	}
+	initial := len(c.Questions) == 0
+	result := &MirrorResult{}
+	var err error
+	if initial || fullRefresh {
+		err = c.mirrorAll(source, pageSize, result, progress)
+	} else {
+		err = c.mirrorNewest(source, pageSize, result, progress)
+	}
+	if err != nil {
+		return result, err
+	}
+	c.UpdatedAt = time.Now()
+	if initial {
+		// Everything is new on the first run, which is not worth reporting
+		result.Added = nil
+	}
+	return result, nil
+}
+
+// merge upserts fetched questions, recording new ones and refreshed acceptance rates
+func (c *Catalog) merge(questions []Question, result *MirrorResult) {
+	for _, question := range questions {
+		if known, ok := c.BySlug(question.TitleSlug); ok && known.AcRate != question.AcRate {
+			result.Refreshed++
+		}
+		if c.Upsert(question) {
+			result.Added = append(result.Added, question)
+		}
+	}
+}
+
+func (c *Catalog) mirrorAll(source ProblemsetSource, pageSize int, result *MirrorResult, progress func(fetched, total int)) error {
+	for skip := 0; ; skip += pageSize {
+		questions, total, err := source.GetProblemsetPage(skip, pageSize, false)
+		if err != nil {
+			return fmt.Errorf("failed to fetch catalog at offset %d: %w", skip, err)
+		}
+		c.merge(questions, result)
+		progress(skip+len(questions), total)
+
+		if len(questions) == 0 || skip+len(questions) >= total {
+			return nil
+		}
+	}
+}
+
+func (c *Catalog) mirrorNewest(source ProblemsetSource, pageSize int, result *MirrorResult, progress func(fetched, total int)) error {
+	known := c.MaxFrontendID()
+
+	for skip := 0; ; skip += pageSize {
+		questions, total, err := source.GetProblemsetPage(skip, pageSize, true)
+		if err != nil {
+			return fmt.Errorf("failed to fetch newest problems at offset %d: %w", skip, err)
+		}
+		c.merge(questions, result)
+		progress(skip+len(questions), total)
+
+		reachedKnown := false
+		for _, question := range questions {
+			if frontendID(question) <= known {
+				reachedKnown = true
+			}
+		}
+		if reachedKnown || len(questions) == 0 || skip+len(questions) >= total {
+			return nil
+		}
+	}
+}
+
+func frontendID(question Question) int {
+	return atoi(question.QuestionFrontendID)
+}
@@ -0,0 +1,124 @@
+package scrapper
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeProblemset serves questions, numbered from 1, the way the catalog query does
+type fakeProblemset struct {
+	questions []Question
+	pages     int
+}
+
+func (f *fakeProblemset) GetProblemsetPage(skip, limit int, newestFirst bool) ([]Question, int, error) {
+	f.pages++
+	ordered := make([]Question, len(f.questions))
+	copy(ordered, f.questions)
+	if newestFirst {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+	return page(ordered, skip, limit), len(ordered), nil
+}
+
+func (f *fakeProblemset) publish(acRate float64) {
+	id := strconv.Itoa(len(f.questions) + 1)
+	f.questions = append(f.questions, Question{QuestionFrontendID: id, TitleSlug: "question-" + id, AcRate: acRate})
+}
+
+func TestMirror(t *testing.T) {
+	source := &fakeProblemset{}
+	for i := 0; i < 25; i++ {
+		source.publish(0.5)
+	}
+
+	catalog, err := LoadCatalog(t.TempDir() + "/catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reported []string
+	result, err := catalog.Mirror(source, 10, false, func(fetched, total int) {
+		reported = append(reported, fmt.Sprintf("%d/%d", fetched, total))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(reported, " "); got != "10/25 20/25 25/25" {
+		t.Errorf("unexpected progress %s", got)
+	}
+	if len(catalog.Questions) != 25 || len(result.Added) != 0 || source.pages != 3 {
+		t.Fatalf("the first run pages through everything and reports nothing new: %d questions, %d added, %d pages",
+			len(catalog.Questions), len(result.Added), source.pages)
+	}
+
+	source.publish(0.4)
+	source.publish(0.3)
+	source.questions[25].AcRate = 0.45
+	source.questions[20].AcRate = 0.55
+	source.questions[0].AcRate = 0.6
+	source.pages = 0
+
+	result, err = catalog.Mirror(source, 10, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 2 || catalog.MaxFrontendID() != 27 || source.pages != 1 {
+		t.Errorf("an update fetches the newest page only: %d added, max ID %d, %d pages", len(result.Added), catalog.MaxFrontendID(), source.pages)
+	}
+	if q, _ := catalog.BySlug("question-21"); q.AcRate != 0.55 || result.Refreshed != 1 {
+		t.Errorf("acceptance rates of the fetched page must be refreshed, got %v and %d refreshed", q.AcRate, result.Refreshed)
+	}
+	if q, _ := catalog.BySlug("question-1"); q.AcRate != 0.5 {
+		t.Errorf("questions beyond the fetched pages wait for a full refresh, got %v", q.AcRate)
+	}
+
+	result, err = catalog.Mirror(source, 10, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := catalog.BySlug("question-1"); q.AcRate != 0.6 || result.Refreshed != 1 || len(result.Added) != 0 {
+		t.Errorf("a full refresh updates every question, got %v, %+v", q.AcRate, result)
+	}
+}
+
+func TestLoadCatalogMigratesPercentages(t *testing.T) {
+	dir := t.TempDir()
+	unversioned := dir + "/percent.json"
+	if err := os.WriteFile(unversioned, []byte(`{"questions":[{"titleSlug":"two-sum","questionFrontendId":"1","acRate":56.45},{"titleSlug":"hard","questionFrontendId":"2","acRate":0.5}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := LoadCatalog(unversioned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := catalog.BySlug("two-sum"); math.Abs(q.AcRate-0.5645) > 1e-9 || catalog.Version != CatalogVersion {
+		t.Errorf("percentages must become fractions, got %v at version %d", q.AcRate, catalog.Version)
+	}
+
+	// a saved catalog is versioned and never converted again, however low its rates
+	saved := dir + "/saved.json"
+	if err := catalog.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadCatalog(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := reloaded.BySlug("hard"); q.AcRate != 0.005 {
+		t.Errorf("a versioned catalog must be read as is, got %v", q.AcRate)
+	}
+
+	future := dir + "/future.json"
+	if err := os.WriteFile(future, []byte(`{"version":99,"questions":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCatalog(future); err == nil {
+		t.Error("catalogs of a newer version must be rejected")
+	}
+}
@@ -0,0 +1,65 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// CompanyTag is a company questions are tagged with
+type CompanyTag struct {
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	QuestionCount int    `json:"questionCount"`
+}
+
+// CompanyTagsResponse is the response of the companyTags operation
+type CompanyTagsResponse struct {
+	Data struct {
+		CompanyTags []CompanyTag `json:"companyTags"`
+	} `json:"data"`
+}
+
+// GetCompanyTags returns every company tag, the most tagged questions first
+func (s *LeetCodeScraper) GetCompanyTags() ([]CompanyTag, error) {
+	query := `
+	query companyTags {
+		companyTags {
+			name
+			slug
+			questionCount
+		}
+	}`
+
+	body, err := s.makeRequest(query, map[string]interface{}{}, "companyTags")
+	if err != nil {
+		return nil, err
+	}
+
+	var response CompanyTagsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	tags := response.Data.CompanyTags
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].QuestionCount > tags[j].QuestionCount
+	})
+	return tags, nil
+}
+
+// ProbeFavoriteList reports whether a favorite list exists and how many questions
+// it holds, fetching a single question of it. Only LeetCode saying the list does
+// not exist makes it missing; any other failure, e.g. an expired session or
+// offline data, is returned.
+func ProbeFavoriteList(repo Repository, favoriteSlug string) (int, bool, error) {
+	response, err := repo.GetFavoriteQuestionList(favoriteSlug, 0, 1)
+	if err != nil {
+		var graphQLErr *GraphQLError
+		if errors.As(err, &graphQLErr) && graphQLErr.NotFound() {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return response.Data.FavoriteQuestionList.TotalLength, true, nil
+}
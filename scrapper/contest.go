@@ -0,0 +1,243 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Contest is a weekly or biweekly contest
+type Contest struct {
+	Title     string `json:"title"`
+	TitleSlug string `json:"titleSlug"`
+	// StartTime is in seconds since the epoch
+	StartTime int64 `json:"startTime"`
+	// Duration is in seconds
+	Duration int `json:"duration"`
+}
+
+// Start returns when the contest starts
+func (c Contest) Start() time.Time {
+	return time.Unix(c.StartTime, 0)
+}
+
+// UpcomingContestsResponse is the response of the upcomingContests operation
+type UpcomingContestsResponse struct {
+	Data struct {
+		UpcomingContests []Contest `json:"upcomingContests"`
+	} `json:"data"`
+}
+
+// PastContestsResponse is the response of the pastContests operation
+type PastContestsResponse struct {
+	Data struct {
+		PastContests struct {
+			PageNum     int       `json:"pageNum"`
+			CurrentPage int       `json:"currentPage"`
+			TotalNum    int       `json:"totalNum"`
+			NumPerPage  int       `json:"numPerPage"`
+			Contests    []Contest `json:"data"`
+		} `json:"pastContests"`
+	} `json:"data"`
+}
+
+// ContestQuestion is a problem of a contest
+type ContestQuestion struct {
+	QuestionID string `json:"questionId"`
+	Title      string `json:"title"`
+	TitleSlug  string `json:"titleSlug"`
+	// Credit is the score the problem is worth
+	Credit int `json:"credit"`
+}
+
+// ContestQuestionListResponse is the response of the contestQuestionList operation
+type ContestQuestionListResponse struct {
+	Data struct {
+		ContestQuestionList []ContestQuestion `json:"contestQuestionList"`
+	} `json:"data"`
+}
+
+// ContestRanking sums up the contests a user took part in
+type ContestRanking struct {
+	AttendedContestsCount int     `json:"attendedContestsCount"`
+	Rating                float64 `json:"rating"`
+	GlobalRanking         int     `json:"globalRanking"`
+	TotalParticipants     int     `json:"totalParticipants"`
+	TopPercentage         float64 `json:"topPercentage"`
+	Badge                 *struct {
+		Name string `json:"name"`
+	} `json:"badge"`
+}
+
+// ContestResult is the result of a user in one contest
+type ContestResult struct {
+	Attended            bool    `json:"attended"`
+	TrendDirection      string  `json:"trendDirection"`
+	ProblemsSolved      int     `json:"problemsSolved"`
+	TotalProblems       int     `json:"totalProblems"`
+	FinishTimeInSeconds int     `json:"finishTimeInSeconds"`
+	Rating              float64 `json:"rating"`
+	Ranking             int     `json:"ranking"`
+	Contest             struct {
+		Title     string `json:"title"`
+		StartTime int64  `json:"startTime"`
+	} `json:"contest"`
+}
+
+// UserContestHistoryResponse is the response of the userContestRankingInfo operation
+type UserContestHistoryResponse struct {
+	Data struct {
+		// UserContestRanking is nil for users who never took part in a contest
+		UserContestRanking        *ContestRanking `json:"userContestRanking"`
+		UserContestRankingHistory []ContestResult `json:"userContestRankingHistory"`
+	} `json:"data"`
+}
+
+// Attended returns the contests the user took part in, oldest first; the history
+// lists every contest since the user registered
+func (r *UserContestHistoryResponse) Attended() []ContestResult {
+	var attended []ContestResult
+	for _, result := range r.Data.UserContestRankingHistory {
+		if result.Attended {
+			attended = append(attended, result)
+		}
+	}
+	return attended
+}
+
+// GetUpcomingContests returns the contests that have not started yet
+func (s *LeetCodeScraper) GetUpcomingContests() ([]Contest, error) {
+	query := `
+	query upcomingContests {
+		upcomingContests {
+			title
+			titleSlug
+			startTime
+			duration
+		}
+	}`
+
+	body, err := s.makeRequest(query, map[string]interface{}{}, "upcomingContests")
+	if err != nil {
+		return nil, err
+	}
+
+	var response UpcomingContestsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return response.Data.UpcomingContests, nil
+}
+
+// GetPastContests returns a page of finished contests, newest first, along with the
+// total number of past contests; pages start at 1
+func (s *LeetCodeScraper) GetPastContests(page, perPage int) ([]Contest, int, error) {
+	query := `
+	query pastContests($pageNo: Int, $numPerPage: Int) {
+		pastContests(pageNo: $pageNo, numPerPage: $numPerPage) {
+			pageNum
+			currentPage
+			totalNum
+			numPerPage
+			data {
+				title
+				titleSlug
+				startTime
+				duration
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"pageNo":     page,
+		"numPerPage": perPage,
+	}
+
+	body, err := s.makeRequest(query, variables, "pastContests")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response PastContestsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return response.Data.PastContests.Contests, response.Data.PastContests.TotalNum, nil
+}
+
+// GetContestQuestions returns the problems of a contest in the order they were posed
+func (s *LeetCodeScraper) GetContestQuestions(contestSlug string) ([]ContestQuestion, error) {
+	query := `
+	query contestQuestionList($contestSlug: String!) {
+		contestQuestionList(contestSlug: $contestSlug) {
+			questionId
+			title
+			titleSlug
+			credit
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"contestSlug": contestSlug,
+	}
+
+	body, err := s.makeRequest(query, variables, "contestQuestionList")
+	if err != nil {
+		return nil, err
+	}
+
+	var response ContestQuestionListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(response.Data.ContestQuestionList) == 0 {
+		return nil, fmt.Errorf("contest %s does not exist or has no problems", contestSlug)
+	}
+	return response.Data.ContestQuestionList, nil
+}
+
+// GetUserContestHistory returns the contest rating and the result of every contest
+// of a user; it is public and needs no session
+func (s *LeetCodeScraper) GetUserContestHistory(username string) (*UserContestHistoryResponse, error) {
+	query := `
+	query userContestRankingInfo($username: String!) {
+		userContestRanking(username: $username) {
+			attendedContestsCount
+			rating
+			globalRanking
+			totalParticipants
+			topPercentage
+			badge {
+				name
+			}
+		}
+		userContestRankingHistory(username: $username) {
+			attended
+			trendDirection
+			problemsSolved
+			totalProblems
+			finishTimeInSeconds
+			rating
+			ranking
+			contest {
+				title
+				startTime
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"username": username,
+	}
+
+	body, err := s.makeRequest(query, variables, "userContestRankingInfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserContestHistoryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
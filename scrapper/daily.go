@@ -0,0 +1,91 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"leetcode-scrapper/cache"
+	"log"
+	"time"
+)
+
+// DailyChallenge is the question of the day
+type DailyChallenge struct {
+	// Date is the day of the challenge in UTC, e.g. 2024-05-01
+	Date string `json:"date"`
+	// UserStatus is Finish once the signed in user solved it, NotStart otherwise
+	UserStatus string             `json:"userStatus"`
+	Link       string             `json:"link"`
+	Question   ProblemsetQuestion `json:"question"`
+}
+
+// DailyChallengeResponse is the response of the questionOfToday operation
+type DailyChallengeResponse struct {
+	Data struct {
+		ActiveDailyCodingChallengeQuestion *DailyChallenge `json:"activeDailyCodingChallengeQuestion"`
+	} `json:"data"`
+}
+
+// GetDailyChallenge fetches the question of the day. A cached challenge is only
+// used on the day it was published.
+func (s *LeetCodeScraper) GetDailyChallenge() (*DailyChallenge, error) {
+	query := `
+	query questionOfToday {
+		activeDailyCodingChallengeQuestion {
+			date
+			userStatus
+			link
+			question {
+				acRate
+				difficulty
+				freqBar
+				questionId
+				frontendQuestionId: questionFrontendId
+				isFavor
+				paidOnly: isPaidOnly
+				status
+				title
+				titleSlug
+				topicTags {
+					name
+					id
+					slug
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{}
+	body, err := s.makeRequest(query, variables, "questionOfToday")
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := parseDailyChallenge(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The challenge changes at midnight UTC, the cache may still hold yesterday's
+	today := time.Now().UTC().Format(time.DateOnly)
+	if challenge.Date == today || s.cache == nil || s.cache.Mode() == cache.ModeOffline {
+		return challenge, nil
+	}
+	body, err = s.fetch(query, variables, "questionOfToday")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Put("questionOfToday", query, variables, body); err != nil {
+		log.Printf("unable to cache questionOfToday response: %v", err)
+	}
+	return parseDailyChallenge(body)
+}
+
+func parseDailyChallenge(body []byte) (*DailyChallenge, error) {
+	var response DailyChallengeResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Data.ActiveDailyCodingChallengeQuestion == nil {
+		return nil, fmt.Errorf("no daily challenge is active")
+	}
+	return response.Data.ActiveDailyCodingChallengeQuestion, nil
+}
@@ -0,0 +1,202 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrNotSignedIn is returned by operations that act on the account of the session
+// when no cookie is set
+var ErrNotSignedIn = errors.New("a LeetCode session is required")
+
+// FavoriteList is a favorite list created by the signed in user
+type FavoriteList struct {
+	Name             string `json:"name"`
+	Slug             string `json:"slug"`
+	QuestionNumber   int    `json:"questionNumber"`
+	IsPublicFavorite bool   `json:"isPublicFavorite"`
+}
+
+// MyFavoriteListsResponse is the response of the myCreatedFavoriteList operation
+type MyFavoriteListsResponse struct {
+	Data struct {
+		MyCreatedFavoriteList struct {
+			Favorites   []FavoriteList `json:"favorites"`
+			HasMore     bool           `json:"hasMore"`
+			TotalLength int            `json:"totalLength"`
+		} `json:"myCreatedFavoriteList"`
+	} `json:"data"`
+}
+
+// MutationResult is what LeetCode answers to a favorite mutation; failures come
+// back as ok false with an error message rather than as GraphQL errors
+type MutationResult struct {
+	Ok           bool   `json:"ok"`
+	Error        string `json:"error"`
+	FavoriteSlug string `json:"favoriteSlug,omitempty"`
+}
+
+// GetMyFavoriteLists returns the favorite lists of the signed in user. Lists change
+// with every mutation, so the answer is never cached.
+func (s *LeetCodeScraper) GetMyFavoriteLists() ([]FavoriteList, error) {
+	if err := s.requireSession(); err != nil {
+		return nil, err
+	}
+
+	query := `
+	query myCreatedFavoriteList($limit: Int, $skip: Int) {
+		myCreatedFavoriteList(limit: $limit, skip: $skip) {
+			favorites {
+				name
+				slug
+				questionNumber
+				isPublicFavorite
+			}
+			hasMore
+			totalLength
+		}
+	}`
+
+	var lists []FavoriteList
+	for {
+		variables := map[string]interface{}{
+			"skip":  len(lists),
+			"limit": 100,
+		}
+		body, err := s.fetch(query, variables, "myCreatedFavoriteList")
+		if err != nil {
+			return nil, err
+		}
+
+		var response MyFavoriteListsResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		page := response.Data.MyCreatedFavoriteList
+		lists = append(lists, page.Favorites...)
+		if !page.HasMore || len(page.Favorites) == 0 {
+			return lists, nil
+		}
+	}
+}
+
+// CreateFavoriteList creates an empty favorite list and returns its slug
+func (s *LeetCodeScraper) CreateFavoriteList(name, description string, public bool) (string, error) {
+	query := `
+	mutation createEmptyFavorite($description: String, $favoriteType: FavoriteTypeEnum!, $isPublic: Boolean, $name: String) {
+		createEmptyFavorite(description: $description, favoriteType: $favoriteType, isPublic: $isPublic, name: $name) {
+			ok
+			error
+			favoriteSlug
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"name":         name,
+		"description":  description,
+		"favoriteType": "NORMAL",
+		"isPublic":     public,
+	}
+
+	result, err := s.mutate(query, variables, "createEmptyFavorite", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create list %q: %w", name, err)
+	}
+	return result.FavoriteSlug, nil
+}
+
+// AddQuestionsToFavorite adds questions to a favorite list of the signed in user
+func (s *LeetCodeScraper) AddQuestionsToFavorite(favoriteSlug string, titleSlugs ...string) error {
+	if len(titleSlugs) == 0 {
+		return nil
+	}
+
+	query := `
+	mutation batchAddQuestionsToFavorite($favoriteSlug: String!, $questionSlugs: [String]!) {
+		batchAddQuestionsToFavorite(favoriteSlug: $favoriteSlug, questionSlugs: $questionSlugs) {
+			ok
+			error
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"favoriteSlug":  favoriteSlug,
+		"questionSlugs": titleSlugs,
+	}
+
+	if _, err := s.mutate(query, variables, "batchAddQuestionsToFavorite", favoriteSlug); err != nil {
+		return fmt.Errorf("failed to add %d questions to %s: %w", len(titleSlugs), favoriteSlug, err)
+	}
+	return nil
+}
+
+// RemoveQuestionFromFavorite removes a question from a favorite list of the signed in user
+func (s *LeetCodeScraper) RemoveQuestionFromFavorite(favoriteSlug, titleSlug string) error {
+	query := `
+	mutation removeQuestionFromFavoriteV2($favoriteSlug: String!, $questionSlug: String!) {
+		removeQuestionFromFavoriteV2(favoriteSlug: $favoriteSlug, questionSlug: $questionSlug) {
+			ok
+			error
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"favoriteSlug": favoriteSlug,
+		"questionSlug": titleSlug,
+	}
+
+	if _, err := s.mutate(query, variables, "removeQuestionFromFavoriteV2", favoriteSlug); err != nil {
+		return fmt.Errorf("failed to remove %s from %s: %w", titleSlug, favoriteSlug, err)
+	}
+	return nil
+}
+
+// mutate sends a mutation whose result is returned under the operation name and
+// drops the cached pages of favoriteSlug, the list it changes. Mutations are sent
+// once: a request that failed with a 5xx status may still have been applied.
+func (s *LeetCodeScraper) mutate(query string, variables interface{}, operationName, favoriteSlug string) (*MutationResult, error) {
+	if err := s.requireSession(); err != nil {
+		return nil, err
+	}
+
+	body, err := s.request(query, variables, operationName, 0)
+	// Even a failed mutation may have changed the list
+	if s.cache != nil && favoriteSlug != "" {
+		_, err := s.cache.InvalidateWhere("favoriteQuestionList", func(variables map[string]interface{}) bool {
+			return variables["favoriteSlug"] == favoriteSlug
+		})
+		if err != nil {
+			log.Printf("unable to invalidate cached pages of %s: %v", favoriteSlug, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data map[string]*MutationResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	result := response.Data[operationName]
+	if result == nil {
+		return nil, fmt.Errorf("%s returned no result", operationName)
+	}
+	if !result.Ok {
+		if result.Error == "" {
+			return nil, fmt.Errorf("%s was rejected", operationName)
+		}
+		return nil, errors.New(result.Error)
+	}
+	return result, nil
+}
+
+func (s *LeetCodeScraper) requireSession() error {
+	if s.headers["Cookie"] == "" {
+		return ErrNotSignedIn
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package scrapper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedFilter is returned by sources that cannot check a criterion of a
+// filter, rather than answering as if it matched everything
+var ErrUnsupportedFilter = errors.New("not supported by this source")
+
+// Filter builds the FiltersV2 input of a question list query, e.g.
+//
+//	NewFilter().Difficulty("MEDIUM", "HARD").Topics("graph").ExcludePremium()
+type Filter struct {
+	v FiltersV2
+
+	acceptance *valueRange
+	frequency  *valueRange
+}
+
+// valueRange is an inclusive range; a negative bound is open
+type valueRange struct {
+	min, max float64
+}
+
+func (r *valueRange) input() map[string]interface{} {
+	input := map[string]interface{}{}
+	if r.min >= 0 {
+		input["rangeLeft"] = r.min
+	}
+	if r.max >= 0 {
+		input["rangeRight"] = r.max
+	}
+	return input
+}
+
+func (r *valueRange) contains(value float64) bool {
+	return (r.min < 0 || value >= r.min) && (r.max < 0 || value <= r.max)
+}
+
+// NewFilter creates a filter that matches every question
+func NewFilter() *Filter {
+	return &Filter{v: emptyFilters()}
+}
+
+func emptyFilters() FiltersV2 {
+	return FiltersV2{
+		FilterCombineType:   "ALL",
+		StatusFilter:        StatusFilter{QuestionStatuses: []string{}, Operator: "IS"},
+		DifficultyFilter:    DifficultyFilter{Difficulties: []string{}, Operator: "IS"},
+		LanguageFilter:      LanguageFilter{LanguageSlugs: []string{}, Operator: "IS"},
+		TopicFilter:         TopicFilter{TopicSlugs: []string{}, Operator: "IS"},
+		AcceptanceFilter:    map[string]interface{}{},
+		FrequencyFilter:     map[string]interface{}{},
+		FrontendIdFilter:    map[string]interface{}{},
+		LastSubmittedFilter: map[string]interface{}{},
+		PublishedFilter:     map[string]interface{}{},
+		CompanyFilter:       CompanyFilter{CompanySlugs: []string{}, Operator: "IS"},
+		PositionFilter:      PositionFilter{PositionSlugs: []string{}, Operator: "IS"},
+		PremiumFilter:       PremiumFilter{PremiumStatus: []string{}, Operator: "IS"},
+	}
+}
+
+// Difficulty keeps questions of the given difficulties: EASY, MEDIUM or HARD
+func (f *Filter) Difficulty(difficulties ...string) *Filter {
+	f.v.DifficultyFilter.Difficulties = upper(difficulties)
+	return f
+}
+
+// Topics keeps questions tagged with the given topic slugs. LeetCode takes a single
+// topic criterion, so this replaces ExcludeTopics.
+func (f *Filter) Topics(slugs ...string) *Filter {
+	f.v.TopicFilter = TopicFilter{TopicSlugs: lower(slugs), Operator: "IS"}
+	return f
+}
+
+// ExcludeTopics drops questions tagged with the given topic slugs; it replaces Topics
+func (f *Filter) ExcludeTopics(slugs ...string) *Filter {
+	f.v.TopicFilter = TopicFilter{TopicSlugs: lower(slugs), Operator: "IS_NOT"}
+	return f
+}
+
+// Status keeps questions with the given statuses: SOLVED, ATTEMPTED or TO_DO
+func (f *Filter) Status(statuses ...string) *Filter {
+	f.v.StatusFilter.QuestionStatuses = upper(statuses)
+	return f
+}
+
+// Companies keeps questions tagged with the given company slugs
+func (f *Filter) Companies(slugs ...string) *Filter {
+	f.v.CompanyFilter.CompanySlugs = lower(slugs)
+	return f
+}
+
+// Languages keeps questions solvable in the given language slugs
+func (f *Filter) Languages(slugs ...string) *Filter {
+	f.v.LanguageFilter.LanguageSlugs = lower(slugs)
+	return f
+}
+
+// ExcludePremium drops questions that need a premium subscription
+func (f *Filter) ExcludePremium() *Filter {
+	f.v.PremiumFilter = PremiumFilter{PremiumStatus: []string{"PREMIUM"}, Operator: "IS_NOT"}
+	return f
+}
+
+// PremiumOnly keeps only questions that need a premium subscription
+func (f *Filter) PremiumOnly() *Filter {
+	f.v.PremiumFilter = PremiumFilter{PremiumStatus: []string{"PREMIUM"}, Operator: "IS"}
+	return f
+}
+
+// Acceptance keeps questions whose acceptance rate, in percent, lies within
+// min and max; pass a negative bound to leave that side open
+func (f *Filter) Acceptance(min, max float64) *Filter {
+	f.acceptance = &valueRange{min: min, max: max}
+	f.v.AcceptanceFilter = f.acceptance.input()
+	return f
+}
+
+// Frequency keeps questions whose frequency, between 0 and 100, lies within
+// min and max; pass a negative bound to leave that side open
+func (f *Filter) Frequency(min, max float64) *Filter {
+	f.frequency = &valueRange{min: min, max: max}
+	f.v.FrequencyFilter = f.frequency.input()
+	return f
+}
+
+// FiltersV2 returns the filter input sent with favorite list queries
+func (f *Filter) FiltersV2() FiltersV2 {
+	if f == nil {
+		return emptyFilters()
+	}
+	return f.v
+}
+
+// ListFilters returns the QuestionListFilterInput sent with catalog queries. That input
+// is older than FiltersV2 and only understands a single difficulty, topic tags, status
+// and premium; Match applies the remaining criteria on the client, except company and
+// language, see clientSupported.
+func (f *Filter) ListFilters() map[string]interface{} {
+	filters := map[string]interface{}{}
+	if f == nil {
+		return filters
+	}
+
+	if len(f.v.DifficultyFilter.Difficulties) == 1 {
+		filters["difficulty"] = f.v.DifficultyFilter.Difficulties[0]
+	}
+	if f.v.TopicFilter.Operator == "IS" && len(f.v.TopicFilter.TopicSlugs) > 0 {
+		filters["tags"] = f.v.TopicFilter.TopicSlugs
+	}
+	if len(f.v.StatusFilter.QuestionStatuses) == 1 {
+		switch f.v.StatusFilter.QuestionStatuses[0] {
+		case "SOLVED":
+			filters["status"] = "AC"
+		case "ATTEMPTED":
+			filters["status"] = "TRIED"
+		case "TO_DO":
+			filters["status"] = "NOT_STARTED"
+		}
+	}
+	if len(f.v.PremiumFilter.PremiumStatus) > 0 {
+		filters["premiumOnly"] = f.v.PremiumFilter.Operator == "IS"
+	}
+	return filters
+}
+
+// listExact reports whether ListFilters expresses every criterion Match checks, so
+// catalog pages need no filtering on the client
+func (f *Filter) listExact() bool {
+	if f == nil {
+		return true
+	}
+	return len(f.v.CompanyFilter.CompanySlugs) == 0 && len(f.v.LanguageFilter.LanguageSlugs) == 0 &&
+		len(f.v.DifficultyFilter.Difficulties) <= 1 &&
+		len(f.v.StatusFilter.QuestionStatuses) <= 1 &&
+		(f.v.TopicFilter.Operator == "IS" || len(f.v.TopicFilter.TopicSlugs) == 0) &&
+		f.acceptance == nil && f.frequency == nil
+}
+
+// clientSupported fails with ErrUnsupportedFilter when the filter has criteria Match
+// cannot check: problems carry neither their companies nor their languages, only
+// favorite list queries sent to LeetCode apply those
+func (f *Filter) clientSupported() error {
+	if f == nil {
+		return nil
+	}
+	var criteria []string
+	if len(f.v.CompanyFilter.CompanySlugs) > 0 {
+		criteria = append(criteria, "company")
+	}
+	if len(f.v.LanguageFilter.LanguageSlugs) > 0 {
+		criteria = append(criteria, "language")
+	}
+	if len(criteria) > 0 {
+		return fmt.Errorf("%s filters: %w", strings.Join(criteria, " and "), ErrUnsupportedFilter)
+	}
+	return nil
+}
+
+// FilterFromV2 rebuilds a filter from a FiltersV2 input as sent with favorite list queries
+func FilterFromV2(v FiltersV2) *Filter {
+	f := NewFilter()
+	f.Difficulty(v.DifficultyFilter.Difficulties...)
+	f.Status(v.StatusFilter.QuestionStatuses...)
+	f.Companies(v.CompanyFilter.CompanySlugs...)
+	f.Languages(v.LanguageFilter.LanguageSlugs...)
+
+	if v.TopicFilter.Operator == "IS_NOT" {
+		f.ExcludeTopics(v.TopicFilter.TopicSlugs...)
+	} else {
+		f.Topics(v.TopicFilter.TopicSlugs...)
+	}
+	if len(v.PremiumFilter.PremiumStatus) > 0 {
+		if v.PremiumFilter.Operator == "IS_NOT" {
+			f.ExcludePremium()
+		} else {
+			f.PremiumOnly()
+		}
+	}
+
+	if r := rangeFromInput(v.AcceptanceFilter); r != nil {
+		f.Acceptance(r.min, r.max)
+	}
+	if r := rangeFromInput(v.FrequencyFilter); r != nil {
+		f.Frequency(r.min, r.max)
+	}
+	return f
+}
+
+// QueryOptions rebuilds the filter and order of a favorite list query, as answered by
+// every server standing in for LeetCode
+func (v FavoriteQuestionListVariables) QueryOptions() ([]QueryOption, error) {
+	opts := []QueryOption{WithFilter(FilterFromV2(v.FiltersV2))}
+	if v.SortBy.SortField != "" {
+		sortBy, err := NewSortBy(v.SortBy.SortField, v.SortBy.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithSort(sortBy))
+	}
+	return opts, nil
+}
+
+// FilterFromList rebuilds a filter from a QuestionListFilterInput as sent with catalog
+// queries; orderBy and sortOrder are not filters and are ignored
+func FilterFromList(filters map[string]interface{}) *Filter {
+	f := NewFilter()
+	if difficulty, ok := filters["difficulty"].(string); ok && difficulty != "" {
+		f.Difficulty(difficulty)
+	}
+	if tags, ok := filters["tags"].([]interface{}); ok {
+		var slugs []string
+		for _, tag := range tags {
+			if slug, ok := tag.(string); ok {
+				slugs = append(slugs, slug)
+			}
+		}
+		f.Topics(slugs...)
+	}
+	switch filters["status"] {
+	case "AC":
+		f.Status("SOLVED")
+	case "TRIED":
+		f.Status("ATTEMPTED")
+	case "NOT_STARTED":
+		f.Status("TO_DO")
+	}
+	if premiumOnly, ok := filters["premiumOnly"].(bool); ok {
+		if premiumOnly {
+			f.PremiumOnly()
+		} else {
+			f.ExcludePremium()
+		}
+	}
+	return f
+}
+
+// rangeFromInput parses a rangeLeft/rangeRight input, nil when neither bound is set
+func rangeFromInput(input map[string]interface{}) *valueRange {
+	r := &valueRange{min: -1, max: -1}
+	set := false
+	if min, ok := input["rangeLeft"].(float64); ok {
+		r.min = min
+		set = true
+	}
+	if max, ok := input["rangeRight"].(float64); ok {
+		r.max = max
+		set = true
+	}
+	if !set {
+		return nil
+	}
+	return r
+}
+
+// Match reports whether a problem satisfies the filter, for data that was not
+// filtered by LeetCode. It cannot check company and language filters, sources
+// calling it reject those first.
+func (f *Filter) Match(p Problem) bool {
+	if f == nil {
+		return true
+	}
+
+	if d := f.v.DifficultyFilter.Difficulties; len(d) > 0 && !contains(d, p.Difficulty) {
+		return false
+	}
+
+	if statuses := f.v.StatusFilter.QuestionStatuses; len(statuses) > 0 {
+		status := p.Status
+		if status == "" {
+			status = "TO_DO"
+		}
+		if !contains(statuses, status) {
+			return false
+		}
+	}
+
+	if slugs := f.v.TopicFilter.TopicSlugs; len(slugs) > 0 {
+		tagged := false
+		for _, tag := range p.TopicTags {
+			if contains(slugs, tag.Slug) {
+				tagged = true
+				break
+			}
+		}
+		if tagged != (f.v.TopicFilter.Operator == "IS") {
+			return false
+		}
+	}
+
+	if len(f.v.PremiumFilter.PremiumStatus) > 0 && p.PaidOnly != (f.v.PremiumFilter.Operator == "IS") {
+		return false
+	}
+
+	if f.acceptance != nil && !f.acceptance.contains(p.AcceptancePercent()) {
+		return false
+	}
+	if f.frequency != nil && !f.frequency.contains(p.Frequency) {
+		return false
+	}
+
+	return true
+}
+
+// QueryOption refines what a question list query returns
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	filter   *Filter
+	sort     *SortBy
+	uncached bool
+}
+
+// sortBy returns the requested order, DefaultSortBy when none was given
+func (q queryOptions) sortBy() SortBy {
+	if q.sort == nil {
+		return DefaultSortBy
+	}
+	return *q.sort
+}
+
+func newQueryOptions(opts []QueryOption) queryOptions {
+	var q queryOptions
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+// WithFilter restricts a query to questions matching the filter
+func WithFilter(filter *Filter) QueryOption {
+	return func(q *queryOptions) {
+		q.filter = filter
+	}
+}
+
+// Uncached reads favorite lists from LeetCode even when the cache holds a fresh
+// answer, e.g. right before changing them; local repositories are never stale and
+// ignore it
+func Uncached() QueryOption {
+	return func(q *queryOptions) {
+		q.uncached = true
+	}
+}
+
+func upper(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strings.ToUpper(strings.TrimSpace(v)))
+	}
+	return out
+}
+
+func lower(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strings.ToLower(strings.TrimSpace(v)))
+	}
+	return out
+}
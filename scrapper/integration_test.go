@@ -0,0 +1,494 @@
+package scrapper_test
+
+import (
+	"errors"
+	"fmt"
+	"leetcode-scrapper/cache"
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/scrapper/scrappertest"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T, questions int) *scrappertest.Server {
+	t.Helper()
+	server := scrappertest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddList("amazon-thirty-days", scrappertest.Questions(questions)...)
+	return server
+}
+
+func TestRetriesTemporaryFailures(t *testing.T) {
+	server := newServer(t, 3)
+	server.Fail(scrappertest.TooManyRequests(0), scrappertest.ServerError())
+
+	response, err := server.Scraper().GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(response.Data.FavoriteQuestionList.Questions); got != 3 {
+		t.Errorf("got %d questions, want 3", got)
+	}
+	if got := len(server.Requests()); got != 3 {
+		t.Errorf("got %d requests, want 2 failures and a success", got)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	server := newServer(t, 1)
+	server.Fail(scrappertest.TooManyRequests(30))
+
+	var slept []time.Duration
+	client := server.Scraper(scrapper.WithSleep(func(d time.Duration) { slept = append(slept, d) }))
+	if _, err := client.GetFavoriteQuestionList("amazon-thirty-days", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if len(slept) != 1 || slept[0] != 30*time.Second {
+		t.Errorf("waited %v, the server asked for 30s", slept)
+	}
+
+	// Waiting longer than the cap fails right away
+	server.Fail(scrappertest.TooManyRequests(3600))
+	slept = nil
+	_, err := client.GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	var statusErr *scrapper.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusTooManyRequests || len(slept) != 0 {
+		t.Errorf("got %v after waiting %v, want a 429 StatusError without retrying", err, slept)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server := newServer(t, 1)
+	server.Fail(scrappertest.ServerError(), scrappertest.ServerError(), scrappertest.ServerError())
+
+	_, err := server.Scraper(scrapper.WithRetry(2, time.Millisecond)).GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	var statusErr *scrapper.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusInternalServerError {
+		t.Fatalf("got %v, want a 500 StatusError", err)
+	}
+	if got := len(server.Requests()); got != 3 {
+		t.Errorf("got %d requests, want the first attempt and 2 retries", got)
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	server := newServer(t, 1)
+	server.Fail(scrappertest.Fault{Status: http.StatusForbidden})
+
+	_, err := server.Scraper().GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	var statusErr *scrapper.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusForbidden {
+		t.Fatalf("got %v, want a 403 StatusError", err)
+	}
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestGraphQLErrorsAreReportedAndNotCached(t *testing.T) {
+	server := newServer(t, 2)
+	server.Fail(scrappertest.GraphQLError("list is private"))
+	s := server.Scraper(scrapper.WithCache(cache.NewStore(t.TempDir())))
+
+	_, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	var gqlErr *scrapper.GraphQLError
+	if !errors.As(err, &gqlErr) || gqlErr.Messages[0] != "list is private" {
+		t.Fatalf("got %v, want a GraphQLError", err)
+	}
+
+	response, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+	if err != nil {
+		t.Fatalf("the error response must not be served from the cache: %v", err)
+	}
+	if got := len(response.Data.FavoriteQuestionList.Questions); got != 2 {
+		t.Errorf("got %d questions, want 2", got)
+	}
+}
+
+func TestSlowResponsesTimeOut(t *testing.T) {
+	server := newServer(t, 1)
+	server.Fail(scrappertest.Slow(200 * time.Millisecond))
+	s := server.Scraper(scrapper.WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}))
+
+	if _, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10); err == nil {
+		t.Fatal("expected a timeout")
+	}
+}
+
+func TestFetchFavoriteListPages(t *testing.T) {
+	server := newServer(t, 23)
+
+	response, err := scrapper.FetchFavoriteList(server.Scraper(), "amazon-thirty-days", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(response.Data.FavoriteQuestionList.Questions); got != 23 {
+		t.Fatalf("got %d questions, want 23", got)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 5 {
+		t.Fatalf("got %d requests, want 5 pages", len(requests))
+	}
+	for i, req := range requests {
+		if req.Skip != i*5 || req.Limit != 5 {
+			t.Errorf("page %d asked for skip %d limit %d", i, req.Skip, req.Limit)
+		}
+	}
+}
+
+func TestFetchFavoriteListInconsistentTotal(t *testing.T) {
+	for name, totalLength := range map[string]func(int) int{
+		"overstated":  func(actual int) int { return actual + 10 },
+		"understated": func(actual int) int { return actual - 10 },
+		"zero":        func(int) int { return 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newServer(t, 23)
+			server.SetTotalLength(totalLength)
+
+			response, err := scrapper.FetchFavoriteList(server.Scraper(), "amazon-thirty-days", 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			list := response.Data.FavoriteQuestionList
+			if len(list.Questions) != 23 || list.TotalLength != 23 {
+				t.Errorf("got %d questions and totalLength %d, want 23", len(list.Questions), list.TotalLength)
+			}
+			if got := len(server.Requests()); got > 6 {
+				t.Errorf("paging did not stop, %d requests", got)
+			}
+		})
+	}
+}
+
+func TestFetchFavoriteListRetriesMidway(t *testing.T) {
+	server := newServer(t, 12)
+	s := server.Scraper()
+
+	if _, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 5); err != nil {
+		t.Fatal(err)
+	}
+	// The second page of the next fetch fails once
+	server.Fail(scrappertest.Fault{}, scrappertest.TooManyRequests(0))
+
+	response, err := scrapper.FetchFavoriteList(s, "amazon-thirty-days", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	questions := response.Data.FavoriteQuestionList.Questions
+	if len(questions) != 12 || questions[5].TitleSlug != "question-6" {
+		t.Errorf("pages were lost or duplicated across the retry: %d questions", len(questions))
+	}
+}
+
+func TestConcurrentRequestsAreSpaced(t *testing.T) {
+	server := newServer(t, 3)
+	interval := 20 * time.Millisecond
+	s := server.Scraper(scrapper.WithRequestInterval(interval))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	requests := server.Requests()
+	for i := 1; i < len(requests); i++ {
+		// Allow for timer granularity
+		if gap := requests[i].Received.Sub(requests[i-1].Received); gap < interval-5*time.Millisecond {
+			t.Errorf("requests %d and %d were only %s apart", i-1, i, gap)
+		}
+	}
+}
+
+func TestUncheckableFiltersAreRejected(t *testing.T) {
+	server := newServer(t, 3)
+	companies := scrapper.WithFilter(scrapper.NewFilter().Companies("google"))
+	languages := scrapper.WithFilter(scrapper.NewFilter().Languages("rust"))
+
+	if _, err := server.Scraper().GetAllProblems(0, 10, companies); !errors.Is(err, scrapper.ErrUnsupportedFilter) {
+		t.Errorf("the catalog query cannot filter by company, got %v", err)
+	}
+	if len(server.Requests()) != 0 {
+		t.Error("rejected queries must not be sent")
+	}
+
+	local := scrapper.NewLocalRepository(t.TempDir(), nil)
+	if _, err := local.GetFavoriteQuestionList("amazon-thirty-days", 0, 10, languages); !errors.Is(err, scrapper.ErrUnsupportedFilter) {
+		t.Errorf("local lists cannot filter by language, got %v", err)
+	}
+	if _, err := local.GetAllProblems(0, 10, companies); !errors.Is(err, scrapper.ErrUnsupportedFilter) {
+		t.Errorf("local data cannot filter by company, got %v", err)
+	}
+
+	// LeetCode applies both to favorite lists
+	if _, err := server.Scraper().GetFavoriteQuestionList("amazon-thirty-days", 0, 10, companies); err != nil {
+		t.Errorf("live favorite lists accept company filters, got %v", err)
+	}
+}
+
+func TestUnknownProblemDetail(t *testing.T) {
+	server := newServer(t, 0)
+
+	if _, err := server.Scraper().GetProblemDetail("missing"); err == nil {
+		t.Fatal("expected an error for a null question")
+	}
+}
+
+func TestSessionHeaders(t *testing.T) {
+	server := newServer(t, 0)
+	server.SetUser(scrapper.UserStatus{UserID: 42, IsSignedIn: true, Username: "alice"})
+	s := server.Scraper(scrapper.WithCookie("LEETCODE_SESSION=jwt; csrftoken=token123"))
+
+	status, err := s.GetUserStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.IsSignedIn || status.Username != "alice" {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	header := server.Requests()[0].Header
+	if got := header.Get("x-csrftoken"); got != "token123" {
+		t.Errorf("x-csrftoken is %q, want the csrftoken cookie", got)
+	}
+	if got := header.Get("Cookie"); got != "LEETCODE_SESSION=jwt; csrftoken=token123" {
+		t.Errorf("unexpected cookie %q", got)
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	server := newServer(t, 0)
+	profile := &scrapper.UserProfileResponse{}
+	profile.Data.MatchedUser = &scrapper.MatchedUser{Username: "alice"}
+	profile.Data.MatchedUser.SubmitStatsGlobal.AcSubmissionNum = []scrapper.SubmissionCount{
+		{Difficulty: "All", Count: 12},
+		{Difficulty: "Hard", Count: 2},
+	}
+	profile.Data.RecentAcSubmissionList = []scrapper.AcceptedSubmission{{Title: "Two Sum", TitleSlug: "two-sum", Timestamp: "1700000000"}}
+	server.AddProfile(profile)
+	s := server.Scraper()
+
+	got, err := s.GetUserProfile("alice", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user := got.Data.MatchedUser; user.Solved("All") != 12 || user.Solved("Hard") != 2 || user.Solved("Easy") != 0 {
+		t.Errorf("unexpected solve counts %+v", user.SubmitStatsGlobal)
+	}
+	if recent := got.Data.RecentAcSubmissionList; len(recent) != 1 || recent[0].Time().Unix() != 1700000000 {
+		t.Errorf("unexpected recent submissions %+v", recent)
+	}
+	if header := server.Requests()[0].Header; header.Get("Cookie") != "" {
+		t.Error("public profiles must not need a session")
+	}
+
+	if _, err := s.GetUserProfile("nobody", 5); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("unknown user: got %v", err)
+	}
+}
+
+func TestDailyChallengeIsRefetchedOnANewDay(t *testing.T) {
+	server := newServer(t, 0)
+	s := server.Scraper(scrapper.WithCache(cache.NewStore(t.TempDir())))
+
+	// cache the challenge of a past day
+	server.SetDaily(&scrapper.DailyChallenge{Date: "2000-01-01", Question: scrapper.ProblemsetQuestion{TitleSlug: "lru-cache"}})
+	if _, err := s.GetDailyChallenge(); err != nil {
+		t.Fatal(err)
+	}
+	seeded := len(server.Requests())
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	server.SetDaily(&scrapper.DailyChallenge{Date: today, Question: scrapper.ProblemsetQuestion{TitleSlug: "two-sum"}})
+
+	for i := 0; i < 2; i++ {
+		challenge, err := s.GetDailyChallenge()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if challenge.Date != today || challenge.Question.TitleSlug != "two-sum" {
+			t.Errorf("got the challenge of %s, %s", challenge.Date, challenge.Question.TitleSlug)
+		}
+	}
+	if n := len(server.Requests()) - seeded; n != 1 {
+		t.Errorf("a stale challenge must be refetched once and then cached, got %d requests", n)
+	}
+}
+
+func TestContests(t *testing.T) {
+	server := newServer(t, 0)
+	server.AddUpcomingContest(scrapper.Contest{Title: "Weekly Contest 401", TitleSlug: "weekly-contest-401", StartTime: 1717900000, Duration: 5400})
+	for _, n := range []int{400, 399, 398} {
+		slug := fmt.Sprintf("weekly-contest-%d", n)
+		server.AddPastContest(scrapper.Contest{TitleSlug: slug}, scrapper.ContestQuestion{TitleSlug: slug + "-q1", Credit: 3})
+	}
+	history := &scrapper.UserContestHistoryResponse{}
+	history.Data.UserContestRanking = &scrapper.ContestRanking{AttendedContestsCount: 1, Rating: 1600}
+	history.Data.UserContestRankingHistory = []scrapper.ContestResult{{Attended: false}, {Attended: true, ProblemsSolved: 3}}
+	server.SetContestHistory("alice", history)
+	s := server.Scraper()
+
+	upcoming, err := s.GetUpcomingContests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upcoming) != 1 || upcoming[0].Start().Unix() != 1717900000 {
+		t.Errorf("unexpected upcoming contests %+v", upcoming)
+	}
+
+	past, total, err := s.GetPastContests(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(past) != 1 || past[0].TitleSlug != "weekly-contest-398" {
+		t.Errorf("second page: %d of %d, %+v", len(past), total, past)
+	}
+
+	questions, err := s.GetContestQuestions("weekly-contest-399")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(questions) != 1 || questions[0].TitleSlug != "weekly-contest-399-q1" {
+		t.Errorf("unexpected questions %+v", questions)
+	}
+	if _, err := s.GetContestQuestions("weekly-contest-1"); err == nil {
+		t.Error("unknown contest: expected an error")
+	}
+
+	got, err := s.GetUserContestHistory("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attended := got.Attended(); len(attended) != 1 || attended[0].ProblemsSolved != 3 {
+		t.Errorf("unexpected attended contests %+v", attended)
+	}
+	if got, err := s.GetUserContestHistory("bob"); err != nil || got.Data.UserContestRanking != nil {
+		t.Errorf("a user without contests: got %+v, %v", got, err)
+	}
+}
+
+func TestFavoriteMutations(t *testing.T) {
+	server := newServer(t, 3)
+	store := cache.NewStore(t.TempDir())
+
+	anonymous := server.Scraper()
+	if _, err := anonymous.CreateFavoriteList("Study", "", false); !errors.Is(err, scrapper.ErrNotSignedIn) {
+		t.Fatalf("mutations need a session, got %v", err)
+	}
+
+	s := server.Scraper(scrapper.WithCookie("LEETCODE_SESSION=jwt; csrftoken=token"), scrapper.WithCache(store))
+	if _, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	slug, err := s.CreateFavoriteList("Study", "hard ones", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scrapper.FetchFavoriteList(s, slug, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddQuestionsToFavorite(slug, "question-1", "question-2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RemoveQuestionFromFavorite(slug, "question-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the list was cached while empty, mutations must drop that copy
+	response, err := scrapper.FetchFavoriteList(s, slug, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if questions := response.Data.FavoriteQuestionList.Questions; len(questions) != 1 || questions[0].TitleSlug != "question-2" {
+		t.Errorf("unexpected list content %+v", questions)
+	}
+
+	lists, err := s.GetMyFavoriteLists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 1 || lists[0].Name != "Study" || lists[0].QuestionNumber != 1 {
+		t.Errorf("unexpected lists %+v", lists)
+	}
+
+	if err := s.AddQuestionsToFavorite("missing-list", "question-1"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("rejected mutation: got %v", err)
+	}
+
+	// A mutation may have been applied before the server failed, it is never sent twice
+	server.Fail(scrappertest.ServerError())
+	sent := len(server.Requests())
+	if err := s.AddQuestionsToFavorite(slug, "question-3"); err == nil {
+		t.Error("the failed mutation must be reported")
+	}
+	if got := len(server.Requests()) - sent; got != 1 {
+		t.Errorf("the mutation was sent %d times", got)
+	}
+
+	// Other lists stay cached, unless read uncached
+	sent = len(server.Requests())
+	if _, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(server.Requests()) - sent; got != 0 {
+		t.Errorf("mutations of %s dropped the cached amazon-thirty-days", slug)
+	}
+	if _, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 10, scrapper.Uncached()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(server.Requests()) - sent; got != 1 {
+		t.Errorf("an uncached read sent %d requests, want 1", got)
+	}
+}
+
+func TestCompanyDiscovery(t *testing.T) {
+	server := newServer(t, 4)
+	server.SetCompanyTags(
+		scrapper.CompanyTag{Name: "Uber", Slug: "uber", QuestionCount: 300},
+		scrapper.CompanyTag{Name: "Amazon", Slug: "amazon", QuestionCount: 1900},
+	)
+	s := server.Scraper()
+
+	tags, err := s.GetCompanyTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].Slug != "amazon" {
+		t.Errorf("tags must be ordered by question count, got %+v", tags)
+	}
+
+	if questions, ok, err := scrapper.ProbeFavoriteList(s, "amazon-thirty-days"); err != nil || !ok || questions != 4 {
+		t.Errorf("existing list: %d, %t, %v", questions, ok, err)
+	}
+	if _, ok, err := scrapper.ProbeFavoriteList(s, "uber-thirty-days"); err != nil || ok {
+		t.Errorf("missing list: %t, %v", ok, err)
+	}
+	server.Fail(scrappertest.Fault{Status: http.StatusForbidden})
+	if _, _, err := scrapper.ProbeFavoriteList(s, "amazon-all"); err == nil {
+		t.Error("failed requests must not pass for missing lists")
+	}
+	server.Fail(scrappertest.GraphQLError("User is not authenticated"))
+	if _, _, err := scrapper.ProbeFavoriteList(s, "amazon-all"); err == nil {
+		t.Error("authentication errors must not pass for missing lists")
+	}
+	if _, _, err := scrapper.ProbeFavoriteList(scrapper.NewLocalRepository(t.TempDir(), nil), "amazon-all"); !errors.Is(err, scrapper.ErrNotAvailableOffline) {
+		t.Errorf("lists missing from offline data are unknown, not missing, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"leetcode-scrapper/cache"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotAvailableOffline is returned for data that was never downloaded
+var ErrNotAvailableOffline = errors.New("not available offline")
+
+// LocalRepository serves queries from favorite lists saved by the downloader
+// and from problem details kept in the response cache
+type LocalRepository struct {
+	dir     string
+	details *cache.Store
+	catalog *Catalog
+}
+
+// NewLocalRepository reads favorite lists from dir; details may be nil
+// when problem details are not needed
+func NewLocalRepository(dir string, details *cache.Store) *LocalRepository {
+	return &LocalRepository{dir: dir, details: details}
+}
+
+// SetCatalog makes GetAllProblems answer from a mirrored problem catalog
+// instead of the union of the saved favorite lists
+func (r *LocalRepository) SetCatalog(catalog *Catalog) {
+	r.catalog = catalog
+}
+
+// FavoriteSlugs returns the slug of every favorite list saved locally
+func (r *LocalRepository) FavoriteSlugs() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		slugs = append(slugs, strings.TrimSuffix(filepath.Base(match), ".json"))
+	}
+	sort.Strings(slugs)
+	return slugs, nil
+}
+
+func (r *LocalRepository) readList(favoriteSlug string) (*FavoriteQuestionListResponse, error) {
+	// Slugs name files in dir and must not reach outside of it
+	if favoriteSlug == "" || strings.HasPrefix(favoriteSlug, ".") || strings.ContainsAny(favoriteSlug, `/\`) {
+		return nil, fmt.Errorf("invalid favorite slug %q", favoriteSlug)
+	}
+	filename := filepath.Join(r.dir, favoriteSlug+".json")
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("favorite list %s: %w", favoriteSlug, ErrNotAvailableOffline)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	var response FavoriteQuestionListResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return &response, nil
+}
+
+// GetFavoriteQuestionList returns a page of a locally saved favorite list; a
+// non-positive limit returns everything after skip
+func (r *LocalRepository) GetFavoriteQuestionList(favoriteSlug string, skip, limit int, opts ...QueryOption) (*FavoriteQuestionListResponse, error) {
+	if err := newQueryOptions(opts).filter.clientSupported(); err != nil {
+		return nil, fmt.Errorf("local data cannot apply %w", err)
+	}
+	response, err := r.readList(favoriteSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return FavoriteListPage(favoriteSlug, response.Data.FavoriteQuestionList.Questions, skip, limit, opts...), nil
+}
+
+// FavoriteListPage answers a favoriteQuestionList query over every question of a list
+// the way LeetCode does: filtered, sorted, then paged, with the total length counting
+// every match
+func FavoriteListPage(favoriteSlug string, questions []Question, skip, limit int, opts ...QueryOption) *FavoriteQuestionListResponse {
+	matched := newQueryOptions(opts).apply(questions, FromList, favoriteSlug)
+
+	var response FavoriteQuestionListResponse
+	list := &response.Data.FavoriteQuestionList
+	list.TotalLength = len(matched)
+	list.Questions = page(matched, skip, limit)
+	list.HasMore = skip+len(list.Questions) < list.TotalLength
+	return &response
+}
+
+// GetAllProblems returns the mirrored catalog when one is set, otherwise the union
+// of every local favorite list ordered by question ID
+func (r *LocalRepository) GetAllProblems(skip, limit int, opts ...QueryOption) ([]Question, error) {
+	options := newQueryOptions(opts)
+	if err := options.filter.clientSupported(); err != nil {
+		return nil, fmt.Errorf("local data cannot apply %w", err)
+	}
+	if r.catalog != nil && len(r.catalog.Questions) > 0 {
+		return page(options.apply(r.catalog.Questions, FromCatalog, ""), skip, limit), nil
+	}
+
+	slugs, err := r.FavoriteSlugs()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var questions []Question
+	for _, slug := range slugs {
+		response, err := r.readList(slug)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range response.Data.FavoriteQuestionList.Questions {
+			if seen[question.TitleSlug] {
+				continue
+			}
+			seen[question.TitleSlug] = true
+			questions = append(questions, question)
+		}
+	}
+
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].ID < questions[j].ID
+	})
+	return page(options.apply(questions, FromList, ""), skip, limit), nil
+}
+
+// GetProblemDetail returns a problem detail previously fetched into the response cache
+func (r *LocalRepository) GetProblemDetail(titleSlug string) (*ProblemDetailResponse, error) {
+	if r.details == nil {
+		return nil, fmt.Errorf("problem %s: %w", titleSlug, ErrNotAvailableOffline)
+	}
+
+	body, err := r.details.Get("questionData", problemDetailQuery, map[string]interface{}{"titleSlug": titleSlug})
+	if err != nil {
+		if errors.Is(err, cache.ErrMiss) {
+			return nil, fmt.Errorf("problem %s: %w", titleSlug, ErrNotAvailableOffline)
+		}
+		return nil, err
+	}
+
+	var response ProblemDetailResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
+
+// apply filters and sorts questions on the client the way LeetCode does on the server
+func (q queryOptions) apply(questions []Question, provenance Provenance, listSlug string) []Question {
+	matched := make([]Question, 0, len(questions))
+	for _, question := range questions {
+		if q.filter.Match(question.Problem(provenance, listSlug)) {
+			matched = append(matched, question)
+		}
+	}
+	SortQuestions(matched, q.sortBy())
+	return matched
+}
+
+// page returns the questions between skip and skip+limit
+func page(questions []Question, skip, limit int) []Question {
+	if skip < 0 {
+		skip = 0
+	}
+	if skip >= len(questions) {
+		return []Question{}
+	}
+	questions = questions[skip:]
+	if limit > 0 && limit < len(questions) {
+		questions = questions[:limit]
+	}
+	return questions
+}
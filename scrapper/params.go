@@ -0,0 +1,153 @@
+package scrapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryParam is a named query parameter understood by ParseQueryOptions. The same
+// names are used for CLI flags and HTTP query strings.
+type QueryParam struct {
+	Name  string
+	Usage string
+	Bool  bool
+}
+
+// QueryParams lists every parameter understood by ParseQueryOptions
+var QueryParams = []QueryParam{
+	{Name: "difficulty", Usage: "comma separated difficulties to keep, e.g. MEDIUM,HARD"},
+	{Name: "topic", Usage: "comma separated topic slugs to keep, e.g. graph,tree"},
+	{Name: "exclude-topic", Usage: "comma separated topic slugs to drop"},
+	{Name: "status", Usage: "comma separated statuses to keep: SOLVED, ATTEMPTED, TO_DO"},
+	{Name: "company", Usage: "comma separated company slugs to keep, live favorite lists only"},
+	{Name: "exclude-premium", Usage: "drop premium-only questions", Bool: true},
+	{Name: "premium-only", Usage: "keep only premium questions", Bool: true},
+	{Name: "min-acceptance", Usage: "minimum acceptance rate in percent"},
+	{Name: "max-acceptance", Usage: "maximum acceptance rate in percent"},
+	{Name: "min-frequency", Usage: "minimum frequency between 0 and 100"},
+	{Name: "max-frequency", Usage: "maximum frequency between 0 and 100"},
+	{Name: "sort", Usage: "sort field: custom, frequency, acceptance, difficulty, id"},
+	{Name: "order", Usage: "sort order: asc or desc"},
+}
+
+// ParseQueryOptions builds query options from named parameters; get returns the
+// value of a parameter or an empty string when it is unset
+func ParseQueryOptions(get func(name string) string) ([]QueryOption, error) {
+	if get("topic") != "" && get("exclude-topic") != "" {
+		return nil, fmt.Errorf("topic and exclude-topic cannot be combined, LeetCode filters topics one way per query")
+	}
+
+	filter := NewFilter()
+	set := false
+
+	lists := []struct {
+		name  string
+		apply func(values ...string) *Filter
+	}{
+		{"difficulty", filter.Difficulty},
+		{"topic", filter.Topics},
+		{"exclude-topic", filter.ExcludeTopics},
+		{"status", filter.Status},
+		{"company", filter.Companies},
+	}
+	for _, list := range lists {
+		if values := SplitList(get(list.name)); len(values) > 0 {
+			list.apply(values...)
+			set = true
+		}
+	}
+
+	excludePremium, err := parseBool("exclude-premium", get("exclude-premium"))
+	if err != nil {
+		return nil, err
+	}
+	premiumOnly, err := parseBool("premium-only", get("premium-only"))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case excludePremium && premiumOnly:
+		return nil, fmt.Errorf("exclude-premium and premium-only cannot be combined")
+	case excludePremium:
+		filter.ExcludePremium()
+		set = true
+	case premiumOnly:
+		filter.PremiumOnly()
+		set = true
+	}
+
+	ranges := []struct {
+		min, max string
+		apply    func(min, max float64) *Filter
+	}{
+		{"min-acceptance", "max-acceptance", filter.Acceptance},
+		{"min-frequency", "max-frequency", filter.Frequency},
+	}
+	for _, r := range ranges {
+		min, err := parseBound(r.min, get(r.min))
+		if err != nil {
+			return nil, err
+		}
+		max, err := parseBound(r.max, get(r.max))
+		if err != nil {
+			return nil, err
+		}
+		if min >= 0 || max >= 0 {
+			r.apply(min, max)
+			set = true
+		}
+	}
+
+	var opts []QueryOption
+	if set {
+		opts = append(opts, WithFilter(filter))
+	}
+
+	if field, order := get("sort"), get("order"); field != "" || order != "" {
+		if field == "" {
+			return nil, fmt.Errorf("order %q needs a sort field", order)
+		}
+		sortBy, err := NewSortBy(field, order)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithSort(sortBy))
+	}
+
+	return opts, nil
+}
+
+// SplitList splits a comma separated value, dropping empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseBool(name, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return enabled, nil
+}
+
+// parseBound parses a range bound, returning -1 for an unset bound
+func parseBound(name, value string) (float64, error) {
+	if value == "" {
+		return -1, nil
+	}
+	bound, err := strconv.ParseFloat(value, 64)
+	if err != nil || bound < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return bound, nil
+}
@@ -0,0 +1,49 @@
+package scrapper
+
+import "testing"
+
+// params serves ParseQueryOptions from a map
+func params(values map[string]string) func(string) string {
+	return func(name string) string {
+		return values[name]
+	}
+}
+
+func TestParseQueryOptions(t *testing.T) {
+	opts, err := ParseQueryOptions(params(map[string]string{"difficulty": "hard", "exclude-topic": "graph", "min-acceptance": "40"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := newQueryOptions(opts).filter
+	v := filter.FiltersV2()
+	if len(v.DifficultyFilter.Difficulties) != 1 || v.TopicFilter.Operator != "IS_NOT" || v.AcceptanceFilter["rangeLeft"] != 40.0 {
+		t.Errorf("unexpected filter %+v", v)
+	}
+	if filter.listExact() {
+		t.Error("exclusions and acceptance ranges must be checked on the client for catalog queries")
+	}
+
+	if _, err := ParseQueryOptions(params(map[string]string{"topic": "tree", "exclude-topic": "graph"})); err == nil {
+		t.Error("topic and exclude-topic must not silently replace each other")
+	}
+	if _, err := ParseQueryOptions(params(map[string]string{"exclude-premium": "true", "premium-only": "true"})); err == nil {
+		t.Error("exclude-premium and premium-only must not silently replace each other")
+	}
+	if _, err := ParseQueryOptions(params(map[string]string{"min-frequency": "-1"})); err == nil {
+		t.Error("negative bounds must be rejected")
+	}
+
+	if _, err := ParseQueryOptions(params(map[string]string{"order": "desc"})); err == nil {
+		t.Error("an order without a sort field must be rejected")
+	}
+	if _, err := ParseQueryOptions(params(map[string]string{"sort": "custom", "order": "desc"})); err == nil {
+		t.Error("the curated order cannot be reversed")
+	}
+	opts, err = ParseQueryOptions(params(map[string]string{"sort": "acceptance", "order": "desc"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := newQueryOptions(opts).sortBy(); got != (SortBy{SortField: SortAcRate, SortOrder: Descending}) {
+		t.Errorf("unexpected sort %+v", got)
+	}
+}
@@ -0,0 +1,228 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provenance tells which kind of response a Problem was converted from
+type Provenance string
+
+const (
+	FromCatalog Provenance = "catalog"
+	FromList    Provenance = "list"
+	FromDetail  Provenance = "detail"
+)
+
+// Acceptance rates are fractions between 0 and 1 everywhere in this package, the way
+// favorite lists report them. Only the catalog query answers with percentages; these
+// two functions are the only conversions between both units.
+func acRateFromPercent(percent float64) float64 { return percent / 100 }
+
+func acRateToPercent(fraction float64) float64 { return fraction * 100 }
+
+// Problem is the normalized view of a question regardless of the response it came from.
+// IDs are always numeric and AcRate is always a fraction between 0 and 1.
+type Problem struct {
+	ID              int        `json:"id"`
+	FrontendID      int        `json:"frontendId"`
+	Title           string     `json:"title"`
+	TitleSlug       string     `json:"titleSlug"`
+	TranslatedTitle string     `json:"translatedTitle,omitempty"`
+	Difficulty      string     `json:"difficulty"`
+	PaidOnly        bool       `json:"paidOnly"`
+	Status          string     `json:"status,omitempty"`
+	Frequency       float64    `json:"frequency"`
+	AcRate          float64    `json:"acRate"`
+	IsInMyFavorites bool       `json:"isInMyFavorites"`
+	TopicTags       []TopicTag `json:"topicTags"`
+	Provenance      Provenance `json:"provenance"`
+	// ListSlug is the favorite list the problem was read from, empty for catalog and detail problems
+	ListSlug string `json:"listSlug,omitempty"`
+}
+
+// ProblemsetQuestion is a question as returned by the problemsetQuestionList operation
+type ProblemsetQuestion struct {
+	AcRate             float64    `json:"acRate"`
+	Difficulty         string     `json:"difficulty"`
+	FreqBar            float64    `json:"freqBar"`
+	QuestionID         string     `json:"questionId"`
+	FrontendQuestionID string     `json:"frontendQuestionId"`
+	IsFavor            bool       `json:"isFavor"`
+	PaidOnly           bool       `json:"paidOnly"`
+	Status             string     `json:"status"`
+	Title              string     `json:"title"`
+	TitleSlug          string     `json:"titleSlug"`
+	TranslatedTitle    string     `json:"translatedTitle"`
+	TopicTags          []TopicTag `json:"topicTags"`
+}
+
+// ProblemsetQuestionListResponse is the response of the problemsetQuestionList operation
+type ProblemsetQuestionListResponse struct {
+	Data struct {
+		ProblemsetQuestionList struct {
+			Total     int                  `json:"total"`
+			Questions []ProblemsetQuestion `json:"questions"`
+		} `json:"problemsetQuestionList"`
+	} `json:"data"`
+}
+
+// Question converts a catalog question into the favorite list shape, turning the
+// acceptance percentage into a fraction
+func (q ProblemsetQuestion) Question() Question {
+	return Question{
+		Difficulty:         q.Difficulty,
+		ID:                 atoi(q.QuestionID),
+		PaidOnly:           q.PaidOnly,
+		QuestionFrontendID: q.FrontendQuestionID,
+		Status:             q.Status,
+		Title:              q.Title,
+		TitleSlug:          q.TitleSlug,
+		TranslatedTitle:    q.TranslatedTitle,
+		IsInMyFavorites:    q.IsFavor,
+		Frequency:          q.FreqBar,
+		AcRate:             acRateFromPercent(q.AcRate),
+		TopicTags:          q.TopicTags,
+	}
+}
+
+// ProblemsetQuestion converts a favorite list question into the catalog shape,
+// turning the acceptance fraction back into a percentage
+func (q Question) ProblemsetQuestion() ProblemsetQuestion {
+	return ProblemsetQuestion{
+		AcRate:             acRateToPercent(q.AcRate),
+		Difficulty:         q.Difficulty,
+		FreqBar:            q.Frequency,
+		QuestionID:         strconv.Itoa(q.ID),
+		FrontendQuestionID: q.QuestionFrontendID,
+		IsFavor:            q.IsInMyFavorites,
+		PaidOnly:           q.PaidOnly,
+		Status:             q.Status,
+		Title:              q.Title,
+		TitleSlug:          q.TitleSlug,
+		TranslatedTitle:    q.TranslatedTitle,
+		TopicTags:          q.TopicTags,
+	}
+}
+
+// Problem converts a question with the given provenance; listSlug names the
+// favorite list for FromList problems
+func (q Question) Problem(provenance Provenance, listSlug string) Problem {
+	return Problem{
+		ID:              q.ID,
+		FrontendID:      atoi(q.QuestionFrontendID),
+		Title:           q.Title,
+		TitleSlug:       q.TitleSlug,
+		TranslatedTitle: q.TranslatedTitle,
+		Difficulty:      strings.ToUpper(q.Difficulty),
+		PaidOnly:        q.PaidOnly,
+		Status:          q.Status,
+		Frequency:       q.Frequency,
+		AcRate:          q.AcRate,
+		IsInMyFavorites: q.IsInMyFavorites,
+		TopicTags:       q.TopicTags,
+		Provenance:      provenance,
+		ListSlug:        listSlug,
+	}
+}
+
+// Question converts a problem back into the favorite list shape
+func (p Problem) Question() Question {
+	return Question{
+		Difficulty:         p.Difficulty,
+		ID:                 p.ID,
+		PaidOnly:           p.PaidOnly,
+		QuestionFrontendID: strconv.Itoa(p.FrontendID),
+		Status:             p.Status,
+		Title:              p.Title,
+		TitleSlug:          p.TitleSlug,
+		TranslatedTitle:    p.TranslatedTitle,
+		IsInMyFavorites:    p.IsInMyFavorites,
+		Frequency:          p.Frequency,
+		AcRate:             p.AcRate,
+		TopicTags:          p.TopicTags,
+	}
+}
+
+// AcceptancePercent returns the acceptance rate as a percentage, for display
+func (p Problem) AcceptancePercent() float64 {
+	return acRateToPercent(p.AcRate)
+}
+
+// URL returns the problem page on LeetCode
+func (p Problem) URL() string {
+	return "https://leetcode.com/problems/" + p.TitleSlug + "/"
+}
+
+// Problems converts every question of a favorite list response
+func (r *FavoriteQuestionListResponse) Problems(favoriteSlug string) []Problem {
+	return ProblemsFromQuestions(r.Data.FavoriteQuestionList.Questions, FromList, favoriteSlug)
+}
+
+// Problems converts every question of a catalog response
+func (r *ProblemsetQuestionListResponse) Problems() []Problem {
+	problems := make([]Problem, 0, len(r.Data.ProblemsetQuestionList.Questions))
+	for _, q := range r.Data.ProblemsetQuestionList.Questions {
+		problems = append(problems, q.Question().Problem(FromCatalog, ""))
+	}
+	return problems
+}
+
+// Problems converts every question of the mirrored catalog
+func (c *Catalog) Problems() []Problem {
+	return ProblemsFromQuestions(c.Questions, FromCatalog, "")
+}
+
+// Problem converts a problem detail response. Details carry no frequency, status
+// or acceptance rate, so those stay zero.
+func (r *ProblemDetailResponse) Problem() Problem {
+	q := r.Data.Question
+	return Problem{
+		ID:              atoi(q.QuestionID),
+		FrontendID:      atoi(q.QuestionFrontendID),
+		Title:           q.Title,
+		TitleSlug:       q.TitleSlug,
+		TranslatedTitle: q.TranslatedTitle,
+		Difficulty:      strings.ToUpper(q.Difficulty),
+		PaidOnly:        q.IsPaidOnly,
+		TopicTags:       q.TopicTags,
+		Provenance:      FromDetail,
+	}
+}
+
+// SimilarQuestion is an entry of the similarQuestions field of a problem detail
+type SimilarQuestion struct {
+	Title      string `json:"title"`
+	TitleSlug  string `json:"titleSlug"`
+	Difficulty string `json:"difficulty"`
+}
+
+// SimilarQuestionList decodes the similarQuestions field, which LeetCode sends as a JSON string
+func (r *ProblemDetailResponse) SimilarQuestionList() ([]SimilarQuestion, error) {
+	raw := r.Data.Question.SimilarQuestions
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var similar []SimilarQuestion
+	if err := json.Unmarshal([]byte(raw), &similar); err != nil {
+		return nil, fmt.Errorf("failed to parse similar questions of %s: %w", r.Data.Question.TitleSlug, err)
+	}
+	return similar, nil
+}
+
+// ProblemsFromQuestions converts questions that all share the same provenance
+func ProblemsFromQuestions(questions []Question, provenance Provenance, listSlug string) []Problem {
+	problems := make([]Problem, 0, len(questions))
+	for _, q := range questions {
+		problems = append(problems, q.Problem(provenance, listSlug))
+	}
+	return problems
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
@@ -0,0 +1,173 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SubmissionCount is the number of accepted questions and submissions of one
+// difficulty; the difficulty "All" totals the others
+type SubmissionCount struct {
+	Difficulty  string `json:"difficulty"`
+	Count       int    `json:"count"`
+	Submissions int    `json:"submissions"`
+}
+
+// LanguageCount is the number of questions solved in one language
+type LanguageCount struct {
+	LanguageName   string `json:"languageName"`
+	ProblemsSolved int    `json:"problemsSolved"`
+}
+
+// TagCount is the number of questions solved of one topic
+type TagCount struct {
+	TagName        string `json:"tagName"`
+	TagSlug        string `json:"tagSlug"`
+	ProblemsSolved int    `json:"problemsSolved"`
+}
+
+// TagCounts are the skill stats of a user, grouped by the level of the topics
+type TagCounts struct {
+	Advanced     []TagCount `json:"advanced"`
+	Intermediate []TagCount `json:"intermediate"`
+	Fundamental  []TagCount `json:"fundamental"`
+}
+
+// Badge is a badge shown on a profile
+type Badge struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	Icon         string `json:"icon"`
+	CreationDate string `json:"creationDate"`
+}
+
+// AcceptedSubmission is one of the recent accepted submissions of a user
+type AcceptedSubmission struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	TitleSlug string `json:"titleSlug"`
+	// Timestamp is in seconds since the epoch, sent as a string
+	Timestamp string `json:"timestamp"`
+}
+
+// Time returns when the submission was accepted
+func (a AcceptedSubmission) Time() time.Time {
+	seconds, _ := strconv.ParseInt(a.Timestamp, 10, 64)
+	return time.Unix(seconds, 0)
+}
+
+// MatchedUser is the public profile of a user
+type MatchedUser struct {
+	Username string `json:"username"`
+	Profile  struct {
+		RealName    string `json:"realName"`
+		Ranking     int    `json:"ranking"`
+		UserAvatar  string `json:"userAvatar"`
+		CountryName string `json:"countryName"`
+	} `json:"profile"`
+	SubmitStatsGlobal struct {
+		AcSubmissionNum []SubmissionCount `json:"acSubmissionNum"`
+	} `json:"submitStatsGlobal"`
+	LanguageProblemCount []LanguageCount `json:"languageProblemCount"`
+	TagProblemCounts     TagCounts       `json:"tagProblemCounts"`
+	Badges               []Badge         `json:"badges"`
+}
+
+// Solved returns the number of accepted questions of a difficulty, All for every difficulty
+func (u *MatchedUser) Solved(difficulty string) int {
+	for _, count := range u.SubmitStatsGlobal.AcSubmissionNum {
+		if count.Difficulty == difficulty {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+// UserProfileResponse is the response of the userPublicProfile operation
+type UserProfileResponse struct {
+	Data struct {
+		MatchedUser            *MatchedUser         `json:"matchedUser"`
+		RecentAcSubmissionList []AcceptedSubmission `json:"recentAcSubmissionList"`
+	} `json:"data"`
+}
+
+// GetUserProfile fetches the public profile of any user along with their last
+// accepted submissions, at most recent of them. The data is public, no session
+// is needed.
+func (s *LeetCodeScraper) GetUserProfile(username string, recent int) (*UserProfileResponse, error) {
+	query := `
+	query userPublicProfile($username: String!, $limit: Int!) {
+		matchedUser(username: $username) {
+			username
+			profile {
+				realName
+				ranking
+				userAvatar
+				countryName
+			}
+			submitStatsGlobal {
+				acSubmissionNum {
+					difficulty
+					count
+					submissions
+				}
+			}
+			languageProblemCount {
+				languageName
+				problemsSolved
+			}
+			tagProblemCounts {
+				advanced {
+					tagName
+					tagSlug
+					problemsSolved
+				}
+				intermediate {
+					tagName
+					tagSlug
+					problemsSolved
+				}
+				fundamental {
+					tagName
+					tagSlug
+					problemsSolved
+				}
+			}
+			badges {
+				id
+				name
+				displayName
+				icon
+				creationDate
+			}
+		}
+		recentAcSubmissionList(username: $username, limit: $limit) {
+			id
+			title
+			titleSlug
+			timestamp
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"username": username,
+		"limit":    recent,
+	}
+
+	body, err := s.makeRequest(query, variables, "userPublicProfile")
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserProfileResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Data.MatchedUser == nil {
+		return nil, fmt.Errorf("user %s does not exist", username)
+	}
+	return &response, nil
+}
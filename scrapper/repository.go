@@ -0,0 +1,45 @@
+package scrapper
+
+import "fmt"
+
+// Repository answers the problem queries the tool needs, either live from
+// LeetCode or from previously downloaded local data
+type Repository interface {
+	GetFavoriteQuestionList(favoriteSlug string, skip, limit int, opts ...QueryOption) (*FavoriteQuestionListResponse, error)
+	GetAllProblems(skip, limit int, opts ...QueryOption) ([]Question, error)
+	GetProblemDetail(titleSlug string) (*ProblemDetailResponse, error)
+}
+
+var (
+	_ Repository = (*LeetCodeScraper)(nil)
+	_ Repository = (*LocalRepository)(nil)
+)
+
+// FetchFavoriteList pages through a whole favorite list and returns it as a single response.
+// Paging goes on while either totalLength or hasMore promises more questions and stops at
+// the first empty page, so an inconsistent totalLength neither truncates nor loops.
+func FetchFavoriteList(repo Repository, favoriteSlug string, pageSize int, opts ...QueryOption) (*FavoriteQuestionListResponse, error) {
+	favoriteResponse, err := repo.GetFavoriteQuestionList(favoriteSlug, 0, pageSize, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", favoriteSlug, err)
+	}
+
+	list := &favoriteResponse.Data.FavoriteQuestionList
+	hasMore := list.HasMore
+	for len(list.Questions) > 0 && (hasMore || len(list.Questions) < list.TotalLength) {
+		res, err := repo.GetFavoriteQuestionList(favoriteSlug, len(list.Questions), pageSize, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s at offset %d: %w", favoriteSlug, len(list.Questions), err)
+		}
+
+		page := res.Data.FavoriteQuestionList.Questions
+		if len(page) == 0 {
+			break
+		}
+		list.Questions = append(list.Questions, page...)
+		hasMore = res.Data.FavoriteQuestionList.HasMore
+	}
+	list.TotalLength = len(list.Questions)
+	list.HasMore = false
+	return favoriteResponse, nil
+}
@@ -0,0 +1,117 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatusError is returned when LeetCode answers with a status other than 200
+type StatusError struct {
+	Code int
+	// RetryAfter is the delay asked for by a Retry-After header, zero when absent
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("received non-200 status code: %d", e.Code)
+}
+
+// Temporary reports whether the request may succeed when sent again
+func (e *StatusError) Temporary() bool {
+	return e.Code == http.StatusTooManyRequests || e.Code >= 500
+}
+
+// GraphQLError is returned when LeetCode answers a query with errors instead of data
+type GraphQLError struct {
+	Operation string
+	Messages  []string
+}
+
+func (e *GraphQLError) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Operation, strings.Join(e.Messages, "; "))
+}
+
+// NotFound reports whether LeetCode answered that the requested object, e.g. a
+// favorite list, does not exist
+func (e *GraphQLError) NotFound() bool {
+	for _, message := range e.Messages {
+		message = strings.ToLower(message)
+		if strings.Contains(message, "does not exist") || strings.Contains(message, "not found") {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxRetryAfter is the longest Retry-After the scraper waits for; requests asked
+// to wait longer fail instead
+const MaxRetryAfter = 60 * time.Second
+
+// WithRetry retries requests rejected with 429 or a 5xx status up to retries times,
+// waiting backoff before the first retry and doubling it after each one. A
+// Retry-After header sent by the server takes precedence over the backoff, up to
+// MaxRetryAfter.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(s *LeetCodeScraper) {
+		s.retries = retries
+		s.backoff = backoff
+	}
+}
+
+// WithSleep replaces the function waiting between retries, e.g. to record delays in tests
+func WithSleep(sleep func(time.Duration)) Option {
+	return func(s *LeetCodeScraper) {
+		s.sleep = sleep
+	}
+}
+
+// retryDelay returns how long to wait before retry number attempt, counting from 0;
+// it fails when the server asks for more than MaxRetryAfter
+func (s *LeetCodeScraper) retryDelay(err *StatusError, attempt int) (time.Duration, error) {
+	if err.RetryAfter > MaxRetryAfter {
+		return 0, fmt.Errorf("server asked to retry after %s, more than %s: %w", err.RetryAfter, MaxRetryAfter, err)
+	}
+	if err.RetryAfter > 0 {
+		return err.RetryAfter, nil
+	}
+	return s.backoff << attempt, nil
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// checkGraphQLErrors returns a GraphQLError when a response body reports errors
+func checkGraphQLErrors(operationName string, body []byte) error {
+	var response struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	// Bodies that are not JSON are left for the caller to reject
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Errors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(response.Errors))
+	for _, e := range response.Errors {
+		messages = append(messages, e.Message)
+	}
+	return &GraphQLError{Operation: operationName, Messages: messages}
+}
@@ -1,16 +1,18 @@
 package scrapper
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"leetcode-scrapper/cache"
 	"leetcode-scrapper/config"
+	"leetcode-scrapper/progress"
+	"log"
 	"math/rand"
 	"net/http"
-	"os"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -126,8 +128,11 @@ type ProblemDetailResponse struct {
 			QuestionFrontendID string     `json:"questionFrontendId"`
 			Title              string     `json:"title"`
 			TitleSlug          string     `json:"titleSlug"`
+			TranslatedTitle    string     `json:"translatedTitle"`
 			IsPaidOnly         bool       `json:"isPaidOnly"`
 			Difficulty         string     `json:"difficulty"`
+			Content            string     `json:"content"`
+			Hints              []string   `json:"hints"`
 			SimilarQuestions   string     `json:"similarQuestions"`
 			ExampleTestcases   string     `json:"exampleTestcases"`
 			TopicTags          []TopicTag `json:"topicTags"`
@@ -142,23 +147,126 @@ type LeetCodeScraper struct {
 	client  *http.Client
 	baseURL string
 	headers map[string]string
+	cache   *cache.Store
+
+	interval    time.Duration
+	retries     int
+	backoff     time.Duration
+	sleep       func(time.Duration)
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// Option configures a LeetCodeScraper
+type Option func(*LeetCodeScraper)
+
+// WithCache puts an on-disk response cache in front of every request
+func WithCache(store *cache.Store) Option {
+	return func(s *LeetCodeScraper) {
+		s.cache = store
+	}
+}
+
+// WithRequestInterval sets the minimum delay between two requests sent to LeetCode
+func WithRequestInterval(interval time.Duration) Option {
+	return func(s *LeetCodeScraper) {
+		s.interval = interval
+	}
+}
+
+// WithBaseURL sends GraphQL requests to another endpoint, e.g. a local facade
+func WithBaseURL(url string) Option {
+	return func(s *LeetCodeScraper) {
+		s.baseURL = url
+	}
+}
+
+// WithTransport sends requests through a custom transport, e.g. a fixture recorder
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *LeetCodeScraper) {
+		s.client.Transport = transport
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for every request
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *LeetCodeScraper) {
+		s.client = client
+	}
+}
+
+// WithCookie sets the cookie sent with every request, see config.LoadCredentials.
+// The csrftoken it contains is echoed in the x-csrftoken header LeetCode checks.
+func WithCookie(cookie string) Option {
+	return func(s *LeetCodeScraper) {
+		delete(s.headers, "Cookie")
+		delete(s.headers, "x-csrftoken")
+
+		session := config.ParseCookie(cookie)
+		if header := session.Header(); header != "" {
+			s.headers["Cookie"] = header
+		}
+		if session.CSRFToken != "" {
+			s.headers["x-csrftoken"] = session.CSRFToken.Reveal()
+		}
+	}
 }
 
 // NewLeetCodeScraper creates a new scraper instance
-func NewLeetCodeScraper() *LeetCodeScraper {
-	return &LeetCodeScraper{
+func NewLeetCodeScraper(opts ...Option) *LeetCodeScraper {
+	s := &LeetCodeScraper{
 		client:  &http.Client{Timeout: 30 * time.Second},
 		baseURL: "https://leetcode.com/graphql/",
 		headers: map[string]string{
 			"Content-Type": "application/json",
 			"Referer":      "https://leetcode.com/",
-			"Cookie":       config.GetApp("config.yaml").Headers.Cookie,
 		},
+		retries: 3,
+		backoff: 2 * time.Second,
+		sleep:   time.Sleep,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// makeRequest makes a GraphQL request to LeetCode
+// makeRequest serves a GraphQL request from the cache when possible and
+// falls back to LeetCode, storing the fresh response
 func (s *LeetCodeScraper) makeRequest(query string, variables interface{}, operationName string) ([]byte, error) {
+	if s.cache == nil {
+		return s.fetch(query, variables, operationName)
+	}
+
+	body, err := s.cache.Get(operationName, query, variables)
+	if err == nil {
+		return body, nil
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		return nil, err
+	}
+	if s.cache.Mode() == cache.ModeOffline {
+		return nil, fmt.Errorf("%s is not cached and offline mode is enabled: %w", operationName, err)
+	}
+
+	body, err = s.fetch(query, variables, operationName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Put(operationName, query, variables, body); err != nil {
+		log.Printf("unable to cache %s response: %v", operationName, err)
+	}
+
+	return body, nil
+}
+
+// fetch makes a GraphQL request to LeetCode, retrying temporary failures
+func (s *LeetCodeScraper) fetch(query string, variables interface{}, operationName string) ([]byte, error) {
+	return s.request(query, variables, operationName, s.retries)
+}
+
+// request makes a GraphQL request, retrying temporary failures up to retries times
+func (s *LeetCodeScraper) request(query string, variables interface{}, operationName string, retries int) ([]byte, error) {
 	reqBody := GraphQLRequest{
 		Query:         query,
 		Variables:     variables,
@@ -170,6 +278,31 @@ func (s *LeetCodeScraper) makeRequest(query string, variables interface{}, opera
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	for attempt := 0; ; attempt++ {
+		body, err := s.send(jsonData)
+		if err == nil {
+			if err := checkGraphQLErrors(operationName, body); err != nil {
+				return nil, err
+			}
+			return body, nil
+		}
+
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || !statusErr.Temporary() || attempt >= retries {
+			return nil, err
+		}
+
+		delay, err := s.retryDelay(statusErr, attempt)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("%s: %v, retrying in %s", operationName, statusErr, delay)
+		s.sleep(delay)
+	}
+}
+
+// send posts one request body and returns the response body
+func (s *LeetCodeScraper) send(jsonData []byte) ([]byte, error) {
 	req, err := http.NewRequest("POST", s.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -179,6 +312,7 @@ func (s *LeetCodeScraper) makeRequest(query string, variables interface{}, opera
 		req.Header.Set(key, value)
 	}
 
+	s.wait()
 	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -186,7 +320,7 @@ func (s *LeetCodeScraper) makeRequest(query string, variables interface{}, opera
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("received non-200 status code: %d", resp.StatusCode)
+		return nil, &StatusError{Code: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -197,7 +331,18 @@ func (s *LeetCodeScraper) makeRequest(query string, variables interface{}, opera
 	return body, nil
 }
 
-func buildQueryAndVariables(favoriteSlug string, skip, limit int) (string, interface{}) {
+// wait blocks until the request interval has passed since the previous request
+func (s *LeetCodeScraper) wait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delay := s.interval - time.Since(s.lastRequest); delay > 0 {
+		time.Sleep(delay)
+	}
+	s.lastRequest = time.Now()
+}
+
+func buildQueryAndVariables(favoriteSlug string, skip, limit int, filter *Filter, sortBy SortBy) (string, interface{}) {
 	query := `
 	query favoriteQuestionList($favoriteSlug: String!, $filter: FavoriteQuestionFilterInput, $filtersV2: QuestionFilterInput, $searchKeyword: String, $sortBy: QuestionSortByInput, $limit: Int, $skip: Int, $version: String = "v2") {
 		favoriteQuestionList(
@@ -234,39 +379,26 @@ func buildQueryAndVariables(favoriteSlug string, skip, limit int) (string, inter
 	}`
 
 	variables := FavoriteQuestionListVariables{
-		Skip:         skip,
-		Limit:        limit,
-		FavoriteSlug: favoriteSlug,
-		FiltersV2: FiltersV2{
-			FilterCombineType:   "ALL",
-			StatusFilter:        StatusFilter{QuestionStatuses: []string{}, Operator: "IS"},
-			DifficultyFilter:    DifficultyFilter{Difficulties: []string{}, Operator: "IS"},
-			LanguageFilter:      LanguageFilter{LanguageSlugs: []string{}, Operator: "IS"},
-			TopicFilter:         TopicFilter{TopicSlugs: []string{}, Operator: "IS"},
-			AcceptanceFilter:    map[string]interface{}{},
-			FrequencyFilter:     map[string]interface{}{},
-			FrontendIdFilter:    map[string]interface{}{},
-			LastSubmittedFilter: map[string]interface{}{},
-			PublishedFilter:     map[string]interface{}{},
-			CompanyFilter:       CompanyFilter{CompanySlugs: []string{}, Operator: "IS"},
-			PositionFilter:      PositionFilter{PositionSlugs: []string{}, Operator: "IS"},
-			PremiumFilter:       PremiumFilter{PremiumStatus: []string{}, Operator: "IS"},
-		},
+		Skip:          skip,
+		Limit:         limit,
+		FavoriteSlug:  favoriteSlug,
+		FiltersV2:     filter.FiltersV2(),
 		SearchKeyword: "",
-		SortBy: SortBy{
-			SortField: "CUSTOM",
-			SortOrder: "ASCENDING",
-		},
+		SortBy:        sortBy,
 	}
 
 	return query, variables
 }
 
 // GetFavoriteQuestionList fetches questions from a favorite list
-func (s *LeetCodeScraper) GetFavoriteQuestionList(favoriteSlug string, skip, limit int) (*FavoriteQuestionListResponse, error) {
-
-	query, variables := buildQueryAndVariables(favoriteSlug, skip, limit)
-	body, err := s.makeRequest(query, variables, "favoriteQuestionList")
+func (s *LeetCodeScraper) GetFavoriteQuestionList(favoriteSlug string, skip, limit int, opts ...QueryOption) (*FavoriteQuestionListResponse, error) {
+	options := newQueryOptions(opts)
+	query, variables := buildQueryAndVariables(favoriteSlug, skip, limit, options.filter, options.sortBy())
+	request := s.makeRequest
+	if options.uncached {
+		request = s.fetch
+	}
+	body, err := request(query, variables, "favoriteQuestionList")
 	if err != nil {
 		return nil, err
 	}
@@ -279,8 +411,60 @@ func (s *LeetCodeScraper) GetFavoriteQuestionList(favoriteSlug string, skip, lim
 	return &response, nil
 }
 
+// catalogPageSize is the page size used when catalog pages are filtered on the client
+const catalogPageSize = 100
+
 // GetAllProblems fetches all problems from LeetCode
-func (s *LeetCodeScraper) GetAllProblems(skip, limit int) ([]Question, error) {
+func (s *LeetCodeScraper) GetAllProblems(skip, limit int, opts ...QueryOption) ([]Question, error) {
+	options := newQueryOptions(opts)
+	if err := options.filter.clientSupported(); err != nil {
+		return nil, fmt.Errorf("the catalog cannot apply %w", err)
+	}
+	filters := options.filter.ListFilters()
+	if sortBy := options.sortBy(); sortBy.SortField != SortCustom {
+		filters["orderBy"] = sortBy.SortField
+		filters["sortOrder"] = sortBy.SortOrder
+	}
+	if options.filter.listExact() {
+		questions, _, err := s.getProblemset(skip, limit, filters, s.makeRequest)
+		return questions, err
+	}
+
+	// The catalog input cannot express every criterion, so LeetCode returns more than
+	// matches; page through it until skip and limit count matching questions only
+	var matched []Question
+	for offset := 0; limit <= 0 || len(matched) < skip+limit; offset += catalogPageSize {
+		questions, total, err := s.getProblemset(offset, catalogPageSize, filters, s.makeRequest)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range questions {
+			if options.filter.Match(q.Problem(FromCatalog, "")) {
+				matched = append(matched, q)
+			}
+		}
+		if len(questions) == 0 || offset+len(questions) >= total {
+			break
+		}
+	}
+	return page(matched, skip, limit), nil
+}
+
+// GetProblemsetPage fetches a page of the whole problem catalog ordered by frontend ID,
+// newest first when requested, together with the total number of problems. Pages are
+// never served from the cache: the mirror relies on them for newly published problems
+// and current acceptance rates.
+func (s *LeetCodeScraper) GetProblemsetPage(skip, limit int, newestFirst bool) ([]Question, int, error) {
+	filters := map[string]interface{}{}
+	if newestFirst {
+		filters["orderBy"] = SortFrontendID
+		filters["sortOrder"] = Descending
+	}
+	return s.getProblemset(skip, limit, filters, s.fetch)
+}
+
+// getProblemset fetches a catalog page through request, makeRequest or fetch
+func (s *LeetCodeScraper) getProblemset(skip, limit int, filters map[string]interface{}, request func(string, interface{}, string) ([]byte, error)) ([]Question, int, error) {
 	query := `
 	query problemsetQuestionList($categorySlug: String, $limit: Int, $skip: Int, $filters: QuestionListFilterInput) {
 		problemsetQuestionList: questionList(
@@ -289,16 +473,19 @@ func (s *LeetCodeScraper) GetAllProblems(skip, limit int) ([]Question, error) {
 			skip: $skip
 			filters: $filters
 		) {
+			total: totalNum
 			questions: data {
 				acRate
 				difficulty
 				freqBar
+				questionId
 				frontendQuestionId: questionFrontendId
 				isFavor
 				paidOnly: isPaidOnly
 				status
 				title
 				titleSlug
+				translatedTitle
 				topicTags {
 					name
 					id
@@ -312,60 +499,31 @@ func (s *LeetCodeScraper) GetAllProblems(skip, limit int) ([]Question, error) {
 		"categorySlug": "",
 		"skip":         skip,
 		"limit":        limit,
-		"filters":      map[string]interface{}{},
+		"filters":      filters,
 	}
 
-	body, err := s.makeRequest(query, variables, "problemsetQuestionList")
+	body, err := request(query, variables, "problemsetQuestionList")
 	if err != nil {
-		return nil, err
-	}
-
-	var response struct {
-		Data struct {
-			ProblemsetQuestionList struct {
-				Questions []struct {
-					AcRate             float64    `json:"acRate"`
-					Difficulty         string     `json:"difficulty"`
-					FreqBar            float64    `json:"freqBar"`
-					FrontendQuestionID string     `json:"frontendQuestionId"`
-					IsFavor            bool       `json:"isFavor"`
-					PaidOnly           bool       `json:"paidOnly"`
-					Status             string     `json:"status"`
-					Title              string     `json:"title"`
-					TitleSlug          string     `json:"titleSlug"`
-					TopicTags          []TopicTag `json:"topicTags"`
-				} `json:"questions"`
-			} `json:"problemsetQuestionList"`
-		} `json:"data"`
+		return nil, 0, err
 	}
 
+	var response ProblemsetQuestionListResponse
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, 0, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	// Convert to our Question structure
-	var questions []Question
+	questions := make([]Question, 0, len(response.Data.ProblemsetQuestionList.Questions))
 	for _, q := range response.Data.ProblemsetQuestionList.Questions {
-		questions = append(questions, Question{
-			Difficulty:         q.Difficulty,
-			PaidOnly:           q.PaidOnly,
-			QuestionFrontendID: q.FrontendQuestionID,
-			Status:             q.Status,
-			Title:              q.Title,
-			TitleSlug:          q.TitleSlug,
-			IsInMyFavorites:    q.IsFavor,
-			Frequency:          q.FreqBar,
-			AcRate:             q.AcRate,
-			TopicTags:          q.TopicTags,
-		})
+		questions = append(questions, q.Question())
 	}
 
-	return questions, nil
+	return questions, response.Data.ProblemsetQuestionList.Total, nil
 }
 
-// GetProblemDetail fetches detailed information for a specific problem
-func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResponse, error) {
-	query := `
+// problemDetailQuery fetches a problem detail; the local repository reads cached
+// answers to it
+const problemDetailQuery = `
 	query questionData($titleSlug: String!) {
 		question(titleSlug: $titleSlug) {
 			questionId
@@ -375,6 +533,8 @@ func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResp
 			translatedTitle
 			isPaidOnly
 			difficulty
+			content
+			hints
 			similarQuestions
 			exampleTestcases
 			contributors {
@@ -384,6 +544,7 @@ func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResp
 				__typename
 			}
 			topicTags {
+				name
 				slug
 				translatedName
 				__typename
@@ -393,11 +554,13 @@ func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResp
 		}
 	}`
 
+// GetProblemDetail fetches detailed information for a specific problem
+func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResponse, error) {
 	variables := map[string]interface{}{
 		"titleSlug": titleSlug,
 	}
 
-	body, err := s.makeRequest(query, variables, "questionData")
+	body, err := s.makeRequest(problemDetailQuery, variables, "questionData")
 	if err != nil {
 		return nil, err
 	}
@@ -406,125 +569,76 @@ func (s *LeetCodeScraper) GetProblemDetail(titleSlug string) (*ProblemDetailResp
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	// Unknown slugs come back as a null question rather than an error
+	if response.Data.Question.TitleSlug == "" {
+		return nil, fmt.Errorf("problem %s does not exist", titleSlug)
+	}
 
 	return &response, nil
 }
 
-func (s *LeetCodeScraper) GetRandomQuestion() {
-	filename := "./updated_data/amazon-thirty-days.json"
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		fmt.Printf("Error reading file: %v\n", err)
-		return
-	}
+// PickOptions controls which questions GetRandomQuestion picks from
+type PickOptions struct {
+	// List is the favorite list to pick from
+	List     string
+	Count    int
+	PageSize int
+	// Solved questions are never picked, nor are questions LeetCode reports as solved
+	Solved *progress.Solved
+}
 
-	var response FavoriteQuestionListResponse
-	err = json.Unmarshal(data, &response)
+// GetRandomQuestion prints a few random unsolved questions from a favorite list
+func GetRandomQuestion(repo Repository, pick PickOptions, opts ...QueryOption) {
+	response, err := FetchFavoriteList(repo, pick.List, pick.PageSize, opts...)
 	if err != nil {
-		fmt.Printf("Error parsing JSON: %v\n", err)
+		fmt.Printf("Error reading favorite list: %v\n", err)
 		return
 	}
-
-	// Read solved titleSlugs from text file
-	solvedSlugs, err := readSolvedSlugs("./data/solved.txt") // Change this to your file path
-	if err != nil {
-		fmt.Printf("Error reading solved slugs: %v\n", err)
-		return
+	if pick.Solved != nil {
+		fmt.Printf("Loaded %d solved questions from '%s'\n", pick.Solved.Len(), pick.Solved.Path())
 	}
 
-	// Extract all titleSlugs
-	questions := response.Data.FavoriteQuestionList.Questions
+	questions := response.Problems(pick.List)
 	if len(questions) == 0 {
 		fmt.Println("No questions found in the JSON file")
 		return
 	}
 
-	// Collect unsolved titleSlugs only
-	var unsolvedTitleSlugs []string
+	// Collect unsolved questions only
+	var unsolved []Problem
 	for _, question := range questions {
-		if question.Status == "SOLVED" || question.Difficulty == "EASY" || question.PaidOnly {
+		if question.Status == "SOLVED" || (pick.Solved != nil && pick.Solved.Has(question.TitleSlug)) {
 			continue
 		}
-		if !contains(solvedSlugs, question.TitleSlug) {
-			unsolvedTitleSlugs = append(unsolvedTitleSlugs, question.TitleSlug)
-		}
+		unsolved = append(unsolved, question)
 	}
 
-	if len(unsolvedTitleSlugs) == 0 {
+	if len(unsolved) == 0 {
 		fmt.Println("All questions have been solved! 🎉")
 		return
 	}
 
-	fmt.Printf("Found %d unsolved questions out of %d total questions\n", len(unsolvedTitleSlugs), len(questions))
-
-	// Print random unsolved titleSlugs (2-3 random ones)
-	printRandomTitleSlugs(unsolvedTitleSlugs)
-}
-
-func printRandomTitleSlugs(titleSlugs []string) {
-	if len(titleSlugs) == 0 {
-		fmt.Println("No unsolved title slugs available")
-		return
-	}
-
-	// Seed the random number generator
-	rand.Seed(time.Now().UnixNano())
+	fmt.Printf("Found %d unsolved questions out of %d total questions\n", len(unsolved), len(questions))
 
-	// Determine how many to print (2 or 3)
-	numToPrint := 2 + rand.Intn(2) // This gives us 2 or 3
-
-	// If we have fewer questions than numToPrint, print all available
-	if len(titleSlugs) < numToPrint {
-		numToPrint = len(titleSlugs)
-	}
-
-	// Create a copy of the slice to avoid modifying the original
-	slugsCopy := make([]string, len(titleSlugs))
-	copy(slugsCopy, titleSlugs)
-
-	// Shuffle and pick random ones
-	fmt.Printf("\nRandom %d unsolved titleSlugs:\n", numToPrint)
-	for i := 0; i < numToPrint; i++ {
-		// Pick a random index from remaining items
-		randomIndex := rand.Intn(len(slugsCopy))
-
-		// Print the selected titleSlug
-		fmt.Printf("%d. %s\n", i+1, slugsCopy[randomIndex])
-
-		// Remove the selected item to avoid duplicates
-		slugsCopy = append(slugsCopy[:randomIndex], slugsCopy[randomIndex+1:]...)
+	picked := PickRandom(unsolved, pick.Count)
+	fmt.Printf("\nRandom %d unsolved titleSlugs:\n", len(picked))
+	for i, problem := range picked {
+		fmt.Printf("%d. %s\n", i+1, problem.TitleSlug)
 	}
 }
 
-// readSolvedSlugs reads the solved titleSlugs from a text file
-func readSolvedSlugs(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		// If file doesn't exist, return empty slice (no solved questions)
-		if os.IsNotExist(err) {
-			fmt.Printf("Solved file '%s' not found, assuming no questions solved yet\n", filename)
-			return []string{}, nil
-		}
-		return nil, err
-	}
-	defer file.Close()
+// PickRandom returns up to n distinct problems chosen at random
+func PickRandom(problems []Problem, n int) []Problem {
+	picked := make([]Problem, len(problems))
+	copy(picked, problems)
+	rand.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
 
-	var solvedSlugs []string
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" { // Skip empty lines
-			solvedSlugs = append(solvedSlugs, line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if n >= 0 && n < len(picked) {
+		picked = picked[:n]
 	}
-
-	fmt.Printf("Loaded %d solved questions from '%s'\n", len(solvedSlugs), filename)
-	return solvedSlugs, nil
+	return picked
 }
 
 // contains checks if a slice contains a specific string
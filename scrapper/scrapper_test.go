@@ -0,0 +1,168 @@
+package scrapper
+
+import (
+	"errors"
+	"leetcode-scrapper/fixture"
+	"math"
+	"testing"
+)
+
+// Fixtures in testdata/fixtures are synthetic, written in the format the --record flag
+// produces: the favorite list pages are cut from updated_data/amazon-thirty-days.json and
+// the catalog pages use made-up totals, with frontend IDs numbered up to the total. Replace
+// them with real responses by recording, e.g.
+//
+//	go run . download --record scrapper/testdata/fixtures --no-cache amazon-thirty-days
+const fixtureDir = "testdata/fixtures"
+
+func newReplayScraper(t *testing.T) (*LeetCodeScraper, *fixture.Replayer) {
+	t.Helper()
+	replayer := fixture.NewReplayer(fixtureDir)
+	s := NewLeetCodeScraper(
+		WithTransport(replayer),
+		WithCookie("LEETCODE_SESSION=test"),
+	)
+	return s, replayer
+}
+
+func slugsOf(questions []Question) []string {
+	slugs := make([]string, 0, len(questions))
+	for _, q := range questions {
+		slugs = append(slugs, q.TitleSlug)
+	}
+	return slugs
+}
+
+func equalSlugs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d questions %v, want %v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("question %d is %s, want %s (got %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestGetFavoriteQuestionList(t *testing.T) {
+	s, _ := newReplayScraper(t)
+
+	response, err := s.GetFavoriteQuestionList("amazon-thirty-days", 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := response.Data.FavoriteQuestionList
+	equalSlugs(t, slugsOf(list.Questions), "two-sum", "longest-substring-without-repeating-characters", "best-time-to-buy-and-sell-stock")
+	if list.TotalLength != 100 || !list.HasMore {
+		t.Errorf("got totalLength %d and hasMore %v, want 100 and true", list.TotalLength, list.HasMore)
+	}
+
+	first := list.Questions[0]
+	if first.ID != 1 || first.QuestionFrontendID != "1" || first.Difficulty != "EASY" {
+		t.Errorf("unexpected first question %+v", first)
+	}
+	if first.AcRate <= 0 || first.AcRate >= 1 {
+		t.Errorf("favorite lists report acceptance as a fraction, got %v", first.AcRate)
+	}
+}
+
+func TestFetchFavoriteListPagination(t *testing.T) {
+	s, replayer := newReplayScraper(t)
+
+	hard := WithFilter(NewFilter().Difficulty("HARD"))
+	response, err := FetchFavoriteList(s, "amazon-thirty-days", 5, hard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := response.Data.FavoriteQuestionList
+	if len(list.Questions) != 11 || list.TotalLength != 11 {
+		t.Fatalf("got %d of %d questions, want all 11", len(list.Questions), list.TotalLength)
+	}
+	if list.HasMore {
+		t.Error("a fully fetched list must not report more questions")
+	}
+	if first, last := list.Questions[0].TitleSlug, list.Questions[10].TitleSlug; first != "trapping-rain-water" || last != "median-of-two-sorted-arrays" {
+		t.Errorf("pages were joined out of order: first %s, last %s", first, last)
+	}
+	for _, q := range list.Questions {
+		if q.Difficulty != "HARD" {
+			t.Errorf("%s is %s, the filter asked for HARD", q.TitleSlug, q.Difficulty)
+		}
+	}
+
+	// Three pages of five: 5 + 5 + 1
+	if served := replayer.Served(); len(served) != 3 {
+		t.Errorf("expected 3 requests, replayed %v", served)
+	}
+}
+
+func TestGetAllProblems(t *testing.T) {
+	s, _ := newReplayScraper(t)
+
+	questions, err := s.GetAllProblems(0, 3, WithFilter(NewFilter().Difficulty("EASY")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalSlugs(t, slugsOf(questions), "two-sum", "palindrome-number", "roman-to-integer")
+
+	// The catalog reports acceptance in percent, questions carry a fraction
+	if got := questions[0].AcRate; math.Abs(got-0.5645) > 0.001 {
+		t.Errorf("acceptance of two-sum is %v, want about 0.5645", got)
+	}
+	if questions[0].ID != 1 || questions[0].QuestionFrontendID != "1" {
+		t.Errorf("unexpected IDs %d and %q", questions[0].ID, questions[0].QuestionFrontendID)
+	}
+}
+
+func TestGetProblemsetPage(t *testing.T) {
+	s, _ := newReplayScraper(t)
+
+	questions, total, err := s.GetProblemsetPage(0, 2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3720 {
+		t.Errorf("total is %d, want 3720", total)
+	}
+	equalSlugs(t, slugsOf(questions), "lexicographically-smallest-permutation-greater-than-target", "compute-decimal-representation")
+}
+
+func TestGetProblemDetail(t *testing.T) {
+	s, _ := newReplayScraper(t)
+
+	response, err := s.GetProblemDetail("two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problem := response.Problem()
+	if problem.ID != 1 || problem.Title != "Two Sum" || problem.Difficulty != "EASY" || problem.Provenance != FromDetail {
+		t.Errorf("unexpected problem %+v", problem)
+	}
+	if len(problem.TopicTags) != 2 || problem.TopicTags[1].Slug != "hash-table" {
+		t.Errorf("unexpected topics %+v", problem.TopicTags)
+	}
+	if len(response.Data.Question.Hints) != 2 || response.Data.Question.Content == "" {
+		t.Error("expected the statement and two hints")
+	}
+
+	similar, err := response.SimilarQuestionList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(similar) != 2 || similar[0].TitleSlug != "3sum" {
+		t.Errorf("unexpected similar questions %+v", similar)
+	}
+}
+
+func TestReplayUnrecordedRequest(t *testing.T) {
+	s, _ := newReplayScraper(t)
+
+	_, err := s.GetProblemDetail("not-recorded")
+	if !errors.Is(err, fixture.ErrNoRecording) {
+		t.Fatalf("got %v, want ErrNoRecording", err)
+	}
+}
@@ -0,0 +1,534 @@
+// Package scrappertest provides an in-process fake of LeetCode's GraphQL endpoint
+// for integration tests, serving favorite lists and problem details from memory
+// and failing on demand
+package scrappertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"leetcode-scrapper/scrapper"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault describes how the server misbehaves for one request; the zero Fault answers normally
+type Fault struct {
+	// Status is sent instead of a response when not zero
+	Status int
+	// RetryAfter is sent as the Retry-After header along with Status
+	RetryAfter string
+	// Delay holds the response back, the request is then answered normally
+	// unless another field is set
+	Delay time.Duration
+	// GraphQLError answers with this error message instead of data
+	GraphQLError string
+}
+
+// TooManyRequests rejects a request with 429, asking to retry after the given seconds
+func TooManyRequests(retryAfter int) Fault {
+	f := Fault{Status: http.StatusTooManyRequests}
+	if retryAfter > 0 {
+		f.RetryAfter = strconv.Itoa(retryAfter)
+	}
+	return f
+}
+
+// ServerError rejects a request with 500
+func ServerError() Fault {
+	return Fault{Status: http.StatusInternalServerError}
+}
+
+// Slow answers a request normally after the given delay
+func Slow(delay time.Duration) Fault {
+	return Fault{Delay: delay}
+}
+
+// GraphQLError answers a request with a GraphQL error
+func GraphQLError(message string) Fault {
+	return Fault{GraphQLError: message}
+}
+
+// Request is a request received by the server
+type Request struct {
+	Operation    string
+	FavoriteSlug string
+	TitleSlug    string
+	Username     string
+	Skip         int
+	Limit        int
+	Header       http.Header
+	Received     time.Time
+}
+
+// Server is a fake LeetCode GraphQL endpoint
+type Server struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	lists       map[string][]scrapper.Question
+	details     map[string]*scrapper.ProblemDetailResponse
+	user        scrapper.UserStatus
+	profiles    map[string]*scrapper.UserProfileResponse
+	daily       *scrapper.DailyChallenge
+	upcoming    []scrapper.Contest
+	contests    []scrapper.Contest
+	problems    map[string][]scrapper.ContestQuestion
+	histories   map[string]*scrapper.UserContestHistoryResponse
+	favorites   []scrapper.FavoriteList
+	companies   []scrapper.CompanyTag
+	faults      []Fault
+	delay       time.Duration
+	totalLength func(actual int) int
+	requests    []Request
+	inFlight    int
+	maxInFlight int
+}
+
+// NewServer starts a server; close it with Close
+func NewServer() *Server {
+	s := &Server{
+		lists:     make(map[string][]scrapper.Question),
+		details:   make(map[string]*scrapper.ProblemDetailResponse),
+		profiles:  make(map[string]*scrapper.UserProfileResponse),
+		problems:  make(map[string][]scrapper.ContestQuestion),
+		histories: make(map[string]*scrapper.UserContestHistoryResponse),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the GraphQL endpoint
+func (s *Server) URL() string {
+	return s.server.URL + "/graphql/"
+}
+
+// Scraper returns a scraper talking to the server without credentials or retry
+// backoff; opts are applied last
+func (s *Server) Scraper(opts ...scrapper.Option) *scrapper.LeetCodeScraper {
+	defaults := []scrapper.Option{
+		scrapper.WithBaseURL(s.URL()),
+		scrapper.WithRetry(3, time.Millisecond),
+	}
+	return scrapper.NewLeetCodeScraper(append(defaults, opts...)...)
+}
+
+// AddList serves questions as the favorite list slug, in the given order
+func (s *Server) AddList(slug string, questions ...scrapper.Question) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists[slug] = append(s.lists[slug], questions...)
+}
+
+// AddDetail serves the detail of a problem
+func (s *Server) AddDetail(detail *scrapper.ProblemDetailResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.details[detail.Data.Question.TitleSlug] = detail
+}
+
+// SetUser answers userStatus queries with user; by default nobody is signed in
+func (s *Server) SetUser(user scrapper.UserStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = user
+}
+
+// AddProfile serves the public profile of a user
+func (s *Server) AddProfile(profile *scrapper.UserProfileResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[profile.Data.MatchedUser.Username] = profile
+}
+
+// SetDaily answers questionOfToday queries with challenge; by default none is active
+func (s *Server) SetDaily(challenge *scrapper.DailyChallenge) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.daily = challenge
+}
+
+// AddUpcomingContest serves a contest that has not started yet
+func (s *Server) AddUpcomingContest(contest scrapper.Contest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upcoming = append(s.upcoming, contest)
+}
+
+// AddPastContest serves a finished contest and its problems; add them newest first
+func (s *Server) AddPastContest(contest scrapper.Contest, questions ...scrapper.ContestQuestion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contests = append(s.contests, contest)
+	s.problems[contest.TitleSlug] = questions
+}
+
+// SetContestHistory serves the contest history of a user; other users never took part in one
+func (s *Server) SetContestHistory(username string, history *scrapper.UserContestHistoryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.histories[username] = history
+}
+
+// SetCompanyTags answers companyTags queries with tags
+func (s *Server) SetCompanyTags(tags ...scrapper.CompanyTag) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.companies = tags
+}
+
+// Fail queues faults; each one is used by exactly one of the next requests, in order
+func (s *Server) Fail(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, faults...)
+}
+
+// SetDelay holds back every response by delay
+func (s *Server) SetDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = delay
+}
+
+// SetTotalLength makes favorite lists report the totalLength returned by fn
+// instead of the actual number of matching questions
+func (s *Server) SetTotalLength(fn func(actual int) int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.totalLength = fn
+}
+
+// Requests returns every request received so far, failed ones included
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// MaxConcurrent returns the highest number of requests that were in flight at once
+func (s *Server) MaxConcurrent() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxInFlight
+}
+
+type graphQLRequest struct {
+	OperationName string `json:"operationName"`
+	Variables     struct {
+		scrapper.FavoriteQuestionListVariables
+		TitleSlug   string `json:"titleSlug"`
+		Username    string `json:"username"`
+		ContestSlug string `json:"contestSlug"`
+		PageNo      int    `json:"pageNo"`
+		NumPerPage  int    `json:"numPerPage"`
+		// favorite mutations
+		Name          string   `json:"name"`
+		QuestionSlug  string   `json:"questionSlug"`
+		QuestionSlugs []string `json:"questionSlugs"`
+	} `json:"variables"`
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vars := req.Variables
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Operation:    req.OperationName,
+		FavoriteSlug: vars.FavoriteSlug,
+		TitleSlug:    vars.TitleSlug,
+		Username:     vars.Username,
+		Skip:         vars.Skip,
+		Limit:        vars.Limit,
+		Header:       r.Header.Clone(),
+		Received:     time.Now(),
+	})
+	var fault Fault
+	if len(s.faults) > 0 {
+		fault, s.faults = s.faults[0], s.faults[1:]
+	}
+	delay := s.delay + fault.Delay
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if fault.Status != 0 {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		http.Error(w, http.StatusText(fault.Status), fault.Status)
+		return
+	}
+	if fault.GraphQLError != "" {
+		writeErrors(w, fault.GraphQLError)
+		return
+	}
+
+	switch req.OperationName {
+	case "favoriteQuestionList":
+		s.favoriteQuestionList(w, vars.FavoriteQuestionListVariables)
+	case "questionData":
+		s.questionData(w, vars.TitleSlug)
+	case "userStatus":
+		var response scrapper.UserStatusResponse
+		s.mu.Lock()
+		response.Data.UserStatus = s.user
+		s.mu.Unlock()
+		writeJSON(w, response)
+	case "questionOfToday":
+		var response scrapper.DailyChallengeResponse
+		s.mu.Lock()
+		response.Data.ActiveDailyCodingChallengeQuestion = s.daily
+		s.mu.Unlock()
+		writeJSON(w, response)
+	case "companyTags":
+		var response scrapper.CompanyTagsResponse
+		s.mu.Lock()
+		response.Data.CompanyTags = append([]scrapper.CompanyTag{}, s.companies...)
+		s.mu.Unlock()
+		writeJSON(w, response)
+	case "upcomingContests":
+		var response scrapper.UpcomingContestsResponse
+		s.mu.Lock()
+		response.Data.UpcomingContests = append([]scrapper.Contest{}, s.upcoming...)
+		s.mu.Unlock()
+		writeJSON(w, response)
+	case "pastContests":
+		s.pastContests(w, vars.PageNo, vars.NumPerPage)
+	case "contestQuestionList":
+		var response scrapper.ContestQuestionListResponse
+		s.mu.Lock()
+		response.Data.ContestQuestionList = s.problems[vars.ContestSlug]
+		s.mu.Unlock()
+		writeJSON(w, response)
+	case "userContestRankingInfo":
+		s.mu.Lock()
+		history, ok := s.histories[vars.Username]
+		s.mu.Unlock()
+		if !ok {
+			history = &scrapper.UserContestHistoryResponse{}
+		}
+		writeJSON(w, history)
+	case "myCreatedFavoriteList", "createEmptyFavorite", "batchAddQuestionsToFavorite", "removeQuestionFromFavoriteV2":
+		if r.Header.Get("Cookie") == "" {
+			writeErrors(w, "User is not authenticated")
+			return
+		}
+		s.favorite(w, req)
+	case "userPublicProfile":
+		s.mu.Lock()
+		profile, ok := s.profiles[vars.Username]
+		s.mu.Unlock()
+		if !ok {
+			writeErrors(w, "That user does not exist.")
+			return
+		}
+		writeJSON(w, profile)
+	default:
+		writeErrors(w, fmt.Sprintf("unsupported operation %q", req.OperationName))
+	}
+}
+
+func (s *Server) favoriteQuestionList(w http.ResponseWriter, vars scrapper.FavoriteQuestionListVariables) {
+	s.mu.Lock()
+	questions, ok := s.lists[vars.FavoriteSlug]
+	totalLength := s.totalLength
+	s.mu.Unlock()
+	if !ok {
+		writeErrors(w, "favorite list does not exist")
+		return
+	}
+
+	opts, err := vars.QueryOptions()
+	if err != nil {
+		writeErrors(w, err.Error())
+		return
+	}
+	response := scrapper.FavoriteListPage(vars.FavoriteSlug, questions, vars.Skip, vars.Limit, opts...)
+	list := &response.Data.FavoriteQuestionList
+	if totalLength != nil {
+		list.TotalLength = totalLength(list.TotalLength)
+	}
+	writeJSON(w, response)
+}
+
+// Favorites returns the favorite lists created through mutations
+func (s *Server) Favorites() []scrapper.FavoriteList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]scrapper.FavoriteList(nil), s.favorites...)
+}
+
+// favorite serves the favorite lists of the signed in user and their mutations;
+// created lists are served as favorite lists too
+func (s *Server) favorite(w http.ResponseWriter, req graphQLRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vars := req.Variables
+
+	index := -1
+	for i, list := range s.favorites {
+		if list.Slug == vars.FavoriteSlug {
+			index = i
+		}
+	}
+	result := func(result scrapper.MutationResult) {
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{req.OperationName: result}})
+	}
+
+	switch req.OperationName {
+	case "myCreatedFavoriteList":
+		var response scrapper.MyFavoriteListsResponse
+		response.Data.MyCreatedFavoriteList.Favorites = append([]scrapper.FavoriteList{}, s.favorites...)
+		response.Data.MyCreatedFavoriteList.TotalLength = len(s.favorites)
+		writeJSON(w, response)
+	case "createEmptyFavorite":
+		slug := fmt.Sprintf("list-%d", len(s.favorites)+1)
+		s.favorites = append(s.favorites, scrapper.FavoriteList{Name: vars.Name, Slug: slug})
+		s.lists[slug] = []scrapper.Question{}
+		result(scrapper.MutationResult{Ok: true, FavoriteSlug: slug})
+	case "batchAddQuestionsToFavorite":
+		if index < 0 {
+			result(scrapper.MutationResult{Error: "favorite list does not exist"})
+			return
+		}
+		for _, slug := range vars.QuestionSlugs {
+			if !containsQuestion(s.lists[vars.FavoriteSlug], slug) {
+				s.lists[vars.FavoriteSlug] = append(s.lists[vars.FavoriteSlug], s.question(slug))
+			}
+		}
+		s.favorites[index].QuestionNumber = len(s.lists[vars.FavoriteSlug])
+		result(scrapper.MutationResult{Ok: true})
+	case "removeQuestionFromFavoriteV2":
+		if index < 0 {
+			result(scrapper.MutationResult{Error: "favorite list does not exist"})
+			return
+		}
+		var kept []scrapper.Question
+		for _, q := range s.lists[vars.FavoriteSlug] {
+			if q.TitleSlug != vars.QuestionSlug {
+				kept = append(kept, q)
+			}
+		}
+		s.lists[vars.FavoriteSlug] = kept
+		s.favorites[index].QuestionNumber = len(kept)
+		result(scrapper.MutationResult{Ok: true})
+	}
+}
+
+// question returns a question served in any list, a bare one for unknown slugs
+func (s *Server) question(titleSlug string) scrapper.Question {
+	for _, questions := range s.lists {
+		for _, q := range questions {
+			if q.TitleSlug == titleSlug {
+				return q
+			}
+		}
+	}
+	return scrapper.Question{Title: titleSlug, TitleSlug: titleSlug}
+}
+
+func containsQuestion(questions []scrapper.Question, titleSlug string) bool {
+	for _, q := range questions {
+		if q.TitleSlug == titleSlug {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) pastContests(w http.ResponseWriter, page, perPage int) {
+	s.mu.Lock()
+	contests := s.contests
+	s.mu.Unlock()
+	if page < 1 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 10
+	}
+
+	var response scrapper.PastContestsResponse
+	past := &response.Data.PastContests
+	past.CurrentPage = page
+	past.NumPerPage = perPage
+	past.TotalNum = len(contests)
+	past.PageNum = (len(contests) + perPage - 1) / perPage
+	past.Contests = []scrapper.Contest{}
+	for i := (page - 1) * perPage; i < len(contests) && i < page*perPage; i++ {
+		past.Contests = append(past.Contests, contests[i])
+	}
+	writeJSON(w, response)
+}
+
+func (s *Server) questionData(w http.ResponseWriter, titleSlug string) {
+	s.mu.Lock()
+	detail, ok := s.details[titleSlug]
+	s.mu.Unlock()
+	if !ok {
+		// LeetCode answers unknown slugs with a null question
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{"question": nil}})
+		return
+	}
+	writeJSON(w, detail)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, message string) {
+	writeJSON(w, map[string]interface{}{
+		"data":   nil,
+		"errors": []map[string]string{{"message": message}},
+	})
+}
+
+// Questions builds n distinct questions numbered from 1, cycling through difficulties,
+// handy for lists whose contents do not matter
+func Questions(n int) []scrapper.Question {
+	difficulties := []string{"EASY", "MEDIUM", "HARD"}
+	questions := make([]scrapper.Question, 0, n)
+	for i := 1; i <= n; i++ {
+		questions = append(questions, scrapper.Question{
+			ID:                 i,
+			QuestionFrontendID: strconv.Itoa(i),
+			Title:              fmt.Sprintf("Question %d", i),
+			TitleSlug:          fmt.Sprintf("question-%d", i),
+			Difficulty:         difficulties[(i-1)%len(difficulties)],
+			Status:             "TO_DO",
+			Frequency:          float64(100 - i%100),
+			AcRate:             0.5,
+		})
+	}
+	return questions
+}
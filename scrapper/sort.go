@@ -0,0 +1,109 @@
+package scrapper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort fields accepted by question list queries
+const (
+	SortCustom     = "CUSTOM"
+	SortFrequency  = "FREQUENCY"
+	SortAcRate     = "AC_RATE"
+	SortDifficulty = "DIFFICULTY"
+	SortFrontendID = "FRONTEND_ID"
+)
+
+// Sort orders accepted by question list queries
+const (
+	Ascending  = "ASCENDING"
+	Descending = "DESCENDING"
+)
+
+// SortFields lists every accepted sort field
+var SortFields = []string{SortCustom, SortFrequency, SortAcRate, SortDifficulty, SortFrontendID}
+
+// sortAliases maps friendlier CLI spellings onto sort fields and orders
+var sortAliases = map[string]string{
+	"acceptance": SortAcRate,
+	"acrate":     SortAcRate,
+	"id":         SortFrontendID,
+	"frontendid": SortFrontendID,
+	"asc":        Ascending,
+	"desc":       Descending,
+}
+
+// DefaultSortBy keeps the order in which a list was curated
+var DefaultSortBy = SortBy{SortField: SortCustom, SortOrder: Ascending}
+
+// NewSortBy validates a sort field and order; an empty order means ascending
+func NewSortBy(field, order string) (SortBy, error) {
+	field = canonicalSort(field)
+	if !contains(SortFields, field) {
+		return SortBy{}, fmt.Errorf("invalid sort field %q, expected one of %s", field, strings.Join(SortFields, ", "))
+	}
+
+	order = canonicalSort(order)
+	if order == "" {
+		order = Ascending
+	}
+	if order != Ascending && order != Descending {
+		return SortBy{}, fmt.Errorf("invalid sort order %q, expected %s or %s", order, Ascending, Descending)
+	}
+	// The curated order has no direction the local repository could reverse
+	if field == SortCustom && order == Descending {
+		return SortBy{}, fmt.Errorf("the %s order cannot be reversed, pick another sort field", SortCustom)
+	}
+
+	return SortBy{SortField: field, SortOrder: order}, nil
+}
+
+func canonicalSort(value string) string {
+	value = strings.TrimSpace(value)
+	key := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(value))
+	if alias, ok := sortAliases[key]; ok {
+		return alias
+	}
+	return strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+}
+
+// WithSort orders the questions a query returns
+func WithSort(sortBy SortBy) QueryOption {
+	return func(q *queryOptions) {
+		q.sort = &sortBy
+	}
+}
+
+var difficultyRank = map[string]int{"EASY": 0, "MEDIUM": 1, "HARD": 2}
+
+// less compares two problems on the sort field only
+func (s SortBy) less(a, b Problem) bool {
+	switch s.SortField {
+	case SortFrequency:
+		return a.Frequency < b.Frequency
+	case SortAcRate:
+		return a.AcRate < b.AcRate
+	case SortDifficulty:
+		return difficultyRank[a.Difficulty] < difficultyRank[b.Difficulty]
+	case SortFrontendID:
+		return a.FrontendID < b.FrontendID
+	}
+	return false
+}
+
+// SortQuestions sorts questions the way LeetCode would for data that was not
+// sorted by the server. CUSTOM keeps the existing order and ties keep their
+// relative order.
+func SortQuestions(questions []Question, sortBy SortBy) {
+	if sortBy.SortField == SortCustom || sortBy.SortField == "" {
+		return
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		a, b := questions[i].Problem(FromList, ""), questions[j].Problem(FromList, "")
+		if sortBy.SortOrder == Descending {
+			return sortBy.less(b, a)
+		}
+		return sortBy.less(a, b)
+	})
+}
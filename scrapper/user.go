@@ -0,0 +1,52 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UserStatus is the signed in user as seen by LeetCode
+type UserStatus struct {
+	UserID     int    `json:"userId"`
+	IsSignedIn bool   `json:"isSignedIn"`
+	IsPremium  bool   `json:"isPremium"`
+	IsVerified bool   `json:"isVerified"`
+	Username   string `json:"username"`
+	RealName   string `json:"realName"`
+	Avatar     string `json:"avatar"`
+}
+
+// UserStatusResponse is the response of the userStatus operation
+type UserStatusResponse struct {
+	Data struct {
+		UserStatus UserStatus `json:"userStatus"`
+	} `json:"data"`
+}
+
+// GetUserStatus asks LeetCode who the session belongs to. It always goes to the
+// network, a cached answer would not tell whether the session is still valid.
+func (s *LeetCodeScraper) GetUserStatus() (*UserStatus, error) {
+	query := `
+	query userStatus {
+		userStatus {
+			userId
+			isSignedIn
+			isPremium
+			isVerified
+			username
+			realName
+			avatar
+		}
+	}`
+
+	body, err := s.fetch(query, map[string]interface{}{}, "userStatus")
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserStatusResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response.Data.UserStatus, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/server"
+	"net/http"
+)
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	solvedFile := fs.String("solved", "data/solved.txt", "solved title slugs read and recorded by the API")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	slugs, err := listSlugs(repo, fs.Args(), rf.config.Companies())
+	if err != nil {
+		return err
+	}
+	solved, err := progress.Load(*solvedFile)
+	if err != nil {
+		return err
+	}
+
+	srv := server.New(repo, slugs, rf.config.Scraper.PageSize, solved)
+	srv.Handle("POST /graphql/", server.NewGraphQL(repo))
+
+	fmt.Printf("Serving %d lists on http://%s/api/ and http://%s/graphql/\n", len(slugs), *addr, *addr)
+	return http.ListenAndServe(*addr, srv)
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"leetcode-scrapper/scrapper"
+	"net/http"
+	"regexp"
+)
+
+// GraphQL answers the favoriteQuestionList, problemsetQuestionList and questionData
+// operations the way LeetCode does, so clients written against leetcode.com/graphql
+// can run against local data. It is not a GraphQL engine: operations are dispatched
+// by name and every response carries the fields, under the aliases, the scraper
+// selects, whatever the query asks for.
+type GraphQL struct {
+	repo scrapper.Repository
+}
+
+// NewGraphQL creates a GraphQL facade answering from repo
+func NewGraphQL(repo scrapper.Repository) *GraphQL {
+	return &GraphQL{repo: repo}
+}
+
+type graphQLRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables"`
+	OperationName string          `json:"operationName"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+var operationPattern = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// ServeHTTP implements http.Handler
+func (g *GraphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, graphQLErrors(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+
+	operation := req.OperationName
+	if operation == "" {
+		if match := operationPattern.FindStringSubmatch(req.Query); match != nil {
+			operation = match[1]
+		}
+	}
+
+	var response interface{}
+	var err error
+	switch operation {
+	case "favoriteQuestionList":
+		response, err = g.favoriteQuestionList(req.Variables)
+	case "problemsetQuestionList":
+		response, err = g.problemsetQuestionList(req.Variables)
+	case "questionData":
+		response, err = g.questionData(req.Variables)
+	default:
+		err = fmt.Errorf("unsupported operation %q", operation)
+	}
+
+	// Like LeetCode, errors are reported in the body of a successful response
+	if err != nil {
+		writeJSON(w, http.StatusOK, graphQLErrors(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (g *GraphQL) favoriteQuestionList(raw json.RawMessage) (interface{}, error) {
+	var vars scrapper.FavoriteQuestionListVariables
+	if err := decodeVariables(raw, &vars); err != nil {
+		return nil, err
+	}
+
+	opts, err := vars.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return g.repo.GetFavoriteQuestionList(vars.FavoriteSlug, vars.Skip, vars.Limit, opts...)
+}
+
+func (g *GraphQL) problemsetQuestionList(raw json.RawMessage) (interface{}, error) {
+	var vars struct {
+		Skip    int                    `json:"skip"`
+		Limit   int                    `json:"limit"`
+		Filters map[string]interface{} `json:"filters"`
+	}
+	if err := decodeVariables(raw, &vars); err != nil {
+		return nil, err
+	}
+
+	opts := []scrapper.QueryOption{scrapper.WithFilter(scrapper.FilterFromList(vars.Filters))}
+	if field, ok := vars.Filters["orderBy"].(string); ok && field != "" {
+		order, _ := vars.Filters["sortOrder"].(string)
+		sortBy, err := scrapper.NewSortBy(field, order)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, scrapper.WithSort(sortBy))
+	}
+
+	// The total counts every match, so fetch them all and page here
+	questions, err := g.repo.GetAllProblems(0, 0, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var response scrapper.ProblemsetQuestionListResponse
+	list := &response.Data.ProblemsetQuestionList
+	list.Total = len(questions)
+	list.Questions = []scrapper.ProblemsetQuestion{}
+	for i := vars.Skip; i < len(questions) && (vars.Limit <= 0 || i < vars.Skip+vars.Limit); i++ {
+		list.Questions = append(list.Questions, questions[i].ProblemsetQuestion())
+	}
+	return &response, nil
+}
+
+func (g *GraphQL) questionData(raw json.RawMessage) (interface{}, error) {
+	var vars struct {
+		TitleSlug string `json:"titleSlug"`
+	}
+	if err := decodeVariables(raw, &vars); err != nil {
+		return nil, err
+	}
+	if vars.TitleSlug == "" {
+		return nil, fmt.Errorf("variable titleSlug is required")
+	}
+	return g.repo.GetProblemDetail(vars.TitleSlug)
+}
+
+func decodeVariables(raw json.RawMessage, vars interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, vars); err != nil {
+		return fmt.Errorf("invalid variables: %w", err)
+	}
+	return nil
+}
+
+func graphQLErrors(err error) map[string]interface{} {
+	return map[string]interface{}{
+		"data":   nil,
+		"errors": []graphQLError{{Message: err.Error()}},
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/scrapper/scrappertest"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newGraphQLScraper points the scraper at the facade over a local repository
+func newGraphQLScraper(t *testing.T, catalog []scrapper.Question, lists map[string][]scrapper.Question) *scrapper.LeetCodeScraper {
+	t.Helper()
+	dir := t.TempDir()
+	for slug, questions := range lists {
+		saveList(t, dir, slug, questions...)
+	}
+	repo := scrapper.NewLocalRepository(dir, nil)
+	if catalog != nil {
+		repo.SetCatalog(&scrapper.Catalog{Questions: catalog})
+	}
+
+	ts := httptest.NewServer(NewGraphQL(repo))
+	t.Cleanup(ts.Close)
+	return scrapper.NewLeetCodeScraper(scrapper.WithBaseURL(ts.URL), scrapper.WithRetry(0, 0))
+}
+
+func TestGraphQLFavoriteQuestionList(t *testing.T) {
+	questions := scrappertest.Questions(7)
+	client := newGraphQLScraper(t, nil, map[string][]scrapper.Question{"google-thirty-days": questions})
+
+	filter := scrapper.NewFilter()
+	filter.Difficulty("MEDIUM", "HARD")
+	sortBy, err := scrapper.NewSortBy("frontend_id", "desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := client.GetFavoriteQuestionList("google-thirty-days", 1, 2, scrapper.WithFilter(filter), scrapper.WithSort(sortBy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := response.Data.FavoriteQuestionList
+	var slugs []string
+	for _, q := range list.Questions {
+		slugs = append(slugs, q.TitleSlug)
+	}
+	// medium and hard are 2, 3, 5 and 6; descending and skipping one leaves 5 and 3
+	if strings.Join(slugs, ",") != "question-5,question-3" || list.TotalLength != 4 || !list.HasMore {
+		t.Errorf("unexpected page %v of %d, hasMore %v", slugs, list.TotalLength, list.HasMore)
+	}
+
+	whole, err := scrapper.FetchFavoriteList(client, "google-thirty-days", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(whole.Data.FavoriteQuestionList.Questions); got != len(questions) {
+		t.Errorf("paging through the list returned %d questions, want %d", got, len(questions))
+	}
+
+	if _, err := client.GetFavoriteQuestionList("amazon-thirty-days", 0, 10); err == nil {
+		t.Error("unknown lists must be reported as GraphQL errors")
+	}
+}
+
+func TestGraphQLProblemset(t *testing.T) {
+	catalog := scrappertest.Questions(250)
+	client := newGraphQLScraper(t, catalog, nil)
+
+	filter := scrapper.NewFilter()
+	filter.Difficulty("EASY")
+	filter.Acceptance(40, 60)
+	problems, err := client.GetAllProblems(80, 5, scrapper.WithFilter(filter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the 84 easy questions are 1, 4, 7...; the 81st is 241
+	if len(problems) != 4 || problems[0].TitleSlug != "question-241" {
+		t.Fatalf("unexpected page %+v", problems)
+	}
+	if problems[0].AcRate != 0.5 {
+		t.Errorf("acceptance rates must survive the percentage round trip, got %v", problems[0].AcRate)
+	}
+
+	questions, total, err := client.GetProblemsetPage(0, 10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != len(catalog) || len(questions) != 10 || questions[0].TitleSlug != "question-250" {
+		t.Errorf("unexpected newest page of %d: %d questions from %v", total, len(questions), questions[0].TitleSlug)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	ts := httptest.NewServer(NewGraphQL(scrapper.NewLocalRepository(filepath.Join(t.TempDir(), "data"), nil)))
+	defer ts.Close()
+
+	for body, want := range map[string]string{
+		`{"operationName":"userStatus","query":"query userStatus { userStatus { username } }"}`: `unsupported operation`,
+		`{"query":"query questionData { question { title } }","variables":{}}`:                  `titleSlug is required`,
+		`{"operationName":"questionData","variables":{"titleSlug":"two-sum"}}`:                  `not available offline`,
+		`not json`: `invalid request body`,
+	} {
+		resp, err := http.Post(ts.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got struct {
+			Errors []graphQLError `json:"errors"`
+		}
+		decodeBody(t, resp, &got)
+		if len(got.Errors) != 1 || !strings.Contains(got.Errors[0].Message, want) {
+			t.Errorf("%s: got %+v, want an error containing %q", body, got.Errors, want)
+		}
+	}
+}
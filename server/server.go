@@ -0,0 +1,296 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/registry"
+	"leetcode-scrapper/scrapper"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errNotFound is wrapped by errors answered with 404
+var errNotFound = errors.New("not found")
+
+// titleSlugPattern matches LeetCode title slugs, e.g. two-sum
+var titleSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Server is a JSON REST API over downloaded favorite lists and solved progress
+type Server struct {
+	repo     scrapper.Repository
+	slugs    []string
+	pageSize int
+	solved   *progress.Solved
+	mux      *http.ServeMux
+}
+
+// Question is a problem as returned by the API
+type Question struct {
+	scrapper.Problem
+	Solved bool `json:"solved"`
+	// Lists names every list the question appears in when querying several lists
+	Lists []string `json:"lists,omitempty"`
+}
+
+type company struct {
+	Name  string `json:"name"`
+	Lists []list `json:"lists"`
+}
+
+type list struct {
+	Slug   string `json:"slug"`
+	Period string `json:"period"`
+}
+
+// New creates a server answering from repo for the given favorite slugs, reading
+// lists pageSize questions at a time; solved questions are read from and recorded
+// into solved
+func New(repo scrapper.Repository, slugs []string, pageSize int, solved *progress.Solved) *Server {
+	s := &Server{repo: repo, slugs: slugs, pageSize: pageSize, solved: solved, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /api/companies", s.handleCompanies)
+	s.mux.HandleFunc("GET /api/questions", s.handleQuestions)
+	s.mux.HandleFunc("GET /api/lists/{slug}/questions", s.handleListQuestions)
+	s.mux.HandleFunc("GET /api/lists/{slug}/random", s.handleRandom)
+	s.mux.HandleFunc("GET /api/solved", s.handleSolved)
+	s.mux.HandleFunc("POST /api/solved", s.handleMarkSolved)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Handle mounts an additional handler, e.g. another API, on the same server
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) handleCompanies(w http.ResponseWriter, r *http.Request) {
+	byName := make(map[string]*company)
+	var names []string
+	for _, slug := range s.slugs {
+		name, period := registry.Split(slug)
+		if byName[name] == nil {
+			byName[name] = &company{Name: name}
+			names = append(names, name)
+		}
+		byName[name].Lists = append(byName[name].Lists, list{Slug: slug, Period: period})
+	}
+
+	sort.Strings(names)
+	companies := make([]company, 0, len(names))
+	for _, name := range names {
+		companies = append(companies, *byName[name])
+	}
+	writeJSON(w, http.StatusOK, companies)
+}
+
+// handleQuestions queries several lists at once, every list unless ?list= names some
+func (s *Server) handleQuestions(w http.ResponseWriter, r *http.Request) {
+	slugs := scrapper.SplitList(r.URL.Query().Get("list"))
+	if len(slugs) == 0 {
+		slugs = s.slugs
+	}
+
+	var questions []Question
+	index := make(map[string]int)
+	for _, slug := range slugs {
+		listQuestions, err := s.query(slug, r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		for _, question := range listQuestions {
+			if i, ok := index[question.TitleSlug]; ok {
+				questions[i].Lists = append(questions[i].Lists, slug)
+				continue
+			}
+			index[question.TitleSlug] = len(questions)
+			question.Lists = []string{slug}
+			question.ListSlug = ""
+			questions = append(questions, question)
+		}
+	}
+	writeJSON(w, http.StatusOK, nonNil(questions))
+}
+
+func (s *Server) handleListQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := s.query(r.PathValue("slug"), r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(questions))
+}
+
+// handleRandom picks ?count= random unsolved questions, 3 by default
+func (s *Server) handleRandom(w http.ResponseWriter, r *http.Request) {
+	count := 3
+	if value := r.URL.Query().Get("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, badRequest(fmt.Errorf("invalid count %q", value)))
+			return
+		}
+		count = n
+	}
+
+	questions, err := s.query(r.PathValue("slug"), r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var unsolved []scrapper.Problem
+	for _, question := range questions {
+		if !question.Solved {
+			unsolved = append(unsolved, question.Problem)
+		}
+	}
+	picked := make([]Question, 0, count)
+	for _, problem := range scrapper.PickRandom(unsolved, count) {
+		picked = append(picked, Question{Problem: problem})
+	}
+	writeJSON(w, http.StatusOK, picked)
+}
+
+func (s *Server) handleSolved(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, nonNil(s.solved.Slugs()))
+}
+
+func (s *Server) handleMarkSolved(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TitleSlug string `json:"titleSlug"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.TitleSlug) == "" {
+		writeError(w, badRequest(fmt.Errorf("expected a JSON body with a titleSlug")))
+		return
+	}
+	if !titleSlugPattern.MatchString(body.TitleSlug) {
+		writeError(w, badRequest(fmt.Errorf("invalid titleSlug %q", body.TitleSlug)))
+		return
+	}
+	if err := s.findQuestion(body.TitleSlug); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	added := s.solved.Add(body.TitleSlug)
+	if added {
+		if err := s.solved.Save(); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, map[string]interface{}{"titleSlug": body.TitleSlug, "added": added})
+}
+
+// findQuestion checks that a question is in one of the served lists, so only
+// questions the API shows can be recorded as solved
+func (s *Server) findQuestion(titleSlug string) error {
+	for _, slug := range s.slugs {
+		response, err := scrapper.FetchFavoriteList(s.repo, slug, s.pageSize)
+		if errors.Is(err, scrapper.ErrNotAvailableOffline) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, question := range response.Data.FavoriteQuestionList.Questions {
+			if question.TitleSlug == titleSlug {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("question %s is in none of the served lists: %w", titleSlug, errNotFound)
+}
+
+// query loads a list with the filters of the request, the same query parameters the
+// CLI accepts as flags, plus ?solved=true|false matched against recorded progress.
+// Only served lists can be queried.
+func (s *Server) query(slug string, r *http.Request) ([]Question, error) {
+	if !slices.Contains(s.slugs, slug) {
+		return nil, fmt.Errorf("unknown list %q: %w", slug, errNotFound)
+	}
+
+	values := r.URL.Query()
+	opts, err := scrapper.ParseQueryOptions(values.Get)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	var wantSolved *bool
+	if value := values.Get("solved"); value != "" {
+		solved, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, badRequest(fmt.Errorf("invalid solved %q", value))
+		}
+		wantSolved = &solved
+	}
+
+	response, err := scrapper.FetchFavoriteList(s.repo, slug, s.pageSize, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []Question
+	for _, problem := range response.Problems(slug) {
+		solved := problem.Status == "SOLVED" || s.solved.Has(problem.TitleSlug)
+		if wantSolved != nil && solved != *wantSolved {
+			continue
+		}
+		questions = append(questions, Question{Problem: problem, Solved: solved})
+	}
+	return questions, nil
+}
+
+type requestError struct {
+	err error
+}
+
+func (e requestError) Error() string { return e.err.Error() }
+func (e requestError) Unwrap() error { return e.err }
+
+func badRequest(err error) error {
+	return requestError{err: err}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var reqErr requestError
+	switch {
+	case errors.As(err, &reqErr), errors.Is(err, scrapper.ErrUnsupportedFilter):
+		status = http.StatusBadRequest
+	case errors.Is(err, errNotFound), errors.Is(err, scrapper.ErrNotAvailableOffline):
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// nonNil makes empty results encode as [] instead of null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
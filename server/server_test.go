@@ -0,0 +1,164 @@
+package server
+
+import (
+	"encoding/json"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/utils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// saveList writes a favorite list the way the downloader does
+func saveList(t *testing.T, dir, slug string, questions ...scrapper.Question) {
+	t.Helper()
+	var response scrapper.FavoriteQuestionListResponse
+	response.Data.FavoriteQuestionList.Questions = questions
+	response.Data.FavoriteQuestionList.TotalLength = len(questions)
+	if err := utils.SaveToFile(response, filepath.Join(dir, slug+".json")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func question(slug, difficulty string, frequency float64) scrapper.Question {
+	return scrapper.Question{TitleSlug: slug, Title: slug, Difficulty: difficulty, Frequency: frequency, QuestionFrontendID: "1"}
+}
+
+// newTestServer serves two google lists; a secret outside the data directory
+// must stay unreachable
+func newTestServer(t *testing.T) (*httptest.Server, *progress.Solved) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "data")
+	saveList(t, dir, "google-thirty-days", question("two-sum", "EASY", 90), question("lru-cache", "MEDIUM", 70))
+	saveList(t, dir, "google-six-months", question("lru-cache", "MEDIUM", 60), question("word-ladder", "HARD", 40))
+	saveList(t, root, "secret", question("leaked", "EASY", 1))
+
+	solved, err := progress.Load(filepath.Join(root, "solved.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	solved.Add("two-sum")
+
+	repo := scrapper.NewLocalRepository(dir, nil)
+	srv := New(repo, []string{"google-thirty-days", "google-six-months"}, 1, solved)
+	srv.Handle("POST /graphql/", NewGraphQL(repo))
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return ts, solved
+}
+
+func get(t *testing.T, url string, v interface{}) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decodeBody(t, resp, v)
+}
+
+// decodeBody decodes a JSON response into v and returns its status
+func decodeBody(t *testing.T, resp *http.Response, v interface{}) int {
+	t.Helper()
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func TestListQuestions(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	var questions []Question
+	if status := get(t, ts.URL+"/api/lists/google-thirty-days/questions?solved=false", &questions); status != http.StatusOK {
+		t.Fatalf("got status %d", status)
+	}
+	if len(questions) != 1 || questions[0].TitleSlug != "lru-cache" {
+		t.Errorf("unexpected unsolved questions %+v", questions)
+	}
+
+	questions = nil
+	get(t, ts.URL+"/api/questions?difficulty=medium,hard&sort=frequency&order=desc", &questions)
+	if len(questions) != 2 || questions[0].TitleSlug != "lru-cache" || len(questions[0].Lists) != 2 {
+		t.Errorf("questions of several lists must be merged: %+v", questions)
+	}
+
+	var companies []company
+	get(t, ts.URL+"/api/companies", &companies)
+	if len(companies) != 1 || companies[0].Name != "google" || len(companies[0].Lists) != 2 {
+		t.Errorf("unexpected companies %+v", companies)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	for path, want := range map[string]int{
+		"/api/lists/google-thirty-days/questions?topic=a&exclude-topic=b": http.StatusBadRequest,
+		"/api/lists/google-thirty-days/random?count=0":                    http.StatusBadRequest,
+		"/api/lists/google-thirty-days/questions?company=google":          http.StatusBadRequest,
+		"/api/lists/amazon-thirty-days/questions":                         http.StatusNotFound,
+		"/api/questions?list=google-thirty-days,amazon-thirty-days":       http.StatusNotFound,
+		// decoded by the router into ../secret
+		"/api/lists/..%2Fsecret/questions": http.StatusNotFound,
+		"/api/questions?list=../secret":    http.StatusNotFound,
+	} {
+		var body map[string]string
+		if status := get(t, ts.URL+path, &body); status != want {
+			t.Errorf("%s: got status %d, want %d", path, status, want)
+		}
+		if strings.Contains(body["error"], "leaked") {
+			t.Errorf("%s: read outside of the data directory: %s", path, body["error"])
+		}
+	}
+}
+
+func TestMarkSolved(t *testing.T) {
+	ts, solved := newTestServer(t)
+
+	post := func(body string) int {
+		t.Helper()
+		resp, err := http.Post(ts.URL+"/api/solved", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(`{"titleSlug":"word-ladder"}`); status != http.StatusCreated {
+		t.Errorf("recording a question: got status %d", status)
+	}
+	if status := post(`{"titleSlug":"word-ladder"}`); status != http.StatusOK {
+		t.Errorf("recording it again: got status %d", status)
+	}
+	for body, want := range map[string]int{
+		`{}`:                            http.StatusBadRequest,
+		`{"titleSlug":"a\nb"}`:          http.StatusBadRequest,
+		`{"titleSlug":"../etc/passwd"}`: http.StatusBadRequest,
+		`{"titleSlug":"not-served"}`:    http.StatusNotFound,
+	} {
+		if status := post(body); status != want {
+			t.Errorf("%s: got status %d, want %d", body, status, want)
+		}
+	}
+
+	data, err := os.ReadFile(solved.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "two-sum\nword-ladder\n" {
+		t.Errorf("unexpected solved file %q", data)
+	}
+
+	var slugs []string
+	get(t, ts.URL+"/api/solved", &slugs)
+	if len(slugs) != 2 {
+		t.Errorf("unexpected solved slugs %v", slugs)
+	}
+}
@@ -0,0 +1,95 @@
+// Package snapshot keeps timestamped copies of downloaded data so changes can be
+// compared over time
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const layout = "20060102-150405"
+
+// Snapshot is one saved copy
+type Snapshot struct {
+	Path  string
+	Taken time.Time
+}
+
+// Save writes data as dir/name/<timestamp>.json and deletes the oldest snapshots of
+// name beyond retain; retain <= 0 keeps everything
+func Save(dir, name string, data interface{}, retain int) (string, error) {
+	folder := filepath.Join(dir, name)
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	filename := filepath.Join(folder, time.Now().UTC().Format(layout)+".json")
+	if err := os.WriteFile(filename, body, 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if retain > 0 {
+		if err := Prune(dir, name, retain); err != nil {
+			return filename, err
+		}
+	}
+	return filename, nil
+}
+
+// List returns the snapshots of name, oldest first
+func List(dir, name string) ([]Snapshot, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, name, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, match := range matches {
+		taken, err := time.Parse(layout, strings.TrimSuffix(filepath.Base(match), ".json"))
+		if err != nil {
+			// Not written by Save
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{Path: match, Taken: taken})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Taken.Before(snapshots[j].Taken)
+	})
+	return snapshots, nil
+}
+
+// Prune deletes all but the newest retain snapshots of name
+func Prune(dir, name string, retain int) error {
+	snapshots, err := List(dir, name)
+	if err != nil {
+		return err
+	}
+	for len(snapshots) > retain {
+		if err := os.Remove(snapshots[0].Path); err != nil {
+			return fmt.Errorf("failed to remove snapshot: %w", err)
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// Load decodes a snapshot into v
+func Load(s Snapshot, v interface{}) error {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", s.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package team
+
+import (
+	"fmt"
+	"io"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"sort"
+	"strings"
+)
+
+// Member is one person of the team and the questions they solved
+type Member struct {
+	Name   string
+	Solved *progress.Solved
+}
+
+// List is a favorite list the team works through
+type List struct {
+	Slug     string
+	Problems []scrapper.Problem
+}
+
+// Coverage is how much of a list a member solved
+type Coverage struct {
+	Member  string
+	Solved  int
+	Total   int
+	Percent float64
+}
+
+// Pairing suggests a problem members who solved it can walk the others through
+type Pairing struct {
+	Problem  scrapper.Problem
+	Solvers  []string
+	Learners []string
+}
+
+// ListReport is the progress of the team on one list
+type ListReport struct {
+	Slug     string
+	Problems []scrapper.Problem
+	// SolvedBy maps title slugs onto the members who solved them, in team order
+	SolvedBy map[string][]string
+	Coverage []Coverage
+	// Unsolved are the problems nobody solved yet
+	Unsolved []scrapper.Problem
+	Pairings []Pairing
+}
+
+// Report is the progress of the team on every list
+type Report struct {
+	Members []string
+	Lists   []ListReport
+	// Overall is the coverage of the distinct problems of all lists
+	Overall []Coverage
+}
+
+// Aggregate reports the progress of members on lists, suggesting at most pairings
+// pairing problems per list
+func Aggregate(members []Member, lists []List, pairings int) Report {
+	report := Report{}
+	for _, m := range members {
+		report.Members = append(report.Members, m.Name)
+	}
+
+	seen := make(map[string]bool)
+	var all []scrapper.Problem
+	for _, list := range lists {
+		report.Lists = append(report.Lists, aggregateList(members, list, pairings))
+		for _, p := range list.Problems {
+			if !seen[p.TitleSlug] {
+				seen[p.TitleSlug] = true
+				all = append(all, p)
+			}
+		}
+	}
+	report.Overall = coverage(members, all)
+	return report
+}
+
+func aggregateList(members []Member, list List, pairings int) ListReport {
+	lr := ListReport{
+		Slug:     list.Slug,
+		Problems: list.Problems,
+		SolvedBy: make(map[string][]string),
+		Coverage: coverage(members, list.Problems),
+	}
+
+	for _, p := range list.Problems {
+		var solvers, learners []string
+		for _, m := range members {
+			if m.Solved.Has(p.TitleSlug) {
+				solvers = append(solvers, m.Name)
+			} else {
+				learners = append(learners, m.Name)
+			}
+		}
+		lr.SolvedBy[p.TitleSlug] = solvers
+
+		switch {
+		case len(solvers) == 0:
+			lr.Unsolved = append(lr.Unsolved, p)
+		case len(learners) > 0:
+			lr.Pairings = append(lr.Pairings, Pairing{Problem: p, Solvers: solvers, Learners: learners})
+		}
+	}
+
+	// The best pairings teach the most members, the most frequently asked problems first
+	sort.SliceStable(lr.Pairings, func(i, j int) bool {
+		a, b := lr.Pairings[i], lr.Pairings[j]
+		if len(a.Learners) != len(b.Learners) {
+			return len(a.Learners) > len(b.Learners)
+		}
+		return a.Problem.Frequency > b.Problem.Frequency
+	})
+	if pairings >= 0 && len(lr.Pairings) > pairings {
+		lr.Pairings = lr.Pairings[:pairings]
+	}
+	return lr
+}
+
+func coverage(members []Member, problems []scrapper.Problem) []Coverage {
+	coverages := make([]Coverage, 0, len(members))
+	for _, m := range members {
+		c := Coverage{Member: m.Name, Total: len(problems)}
+		for _, p := range problems {
+			if m.Solved.Has(p.TitleSlug) {
+				c.Solved++
+			}
+		}
+		if c.Total > 0 {
+			c.Percent = float64(c.Solved) * 100 / float64(c.Total)
+		}
+		coverages = append(coverages, c)
+	}
+	return coverages
+}
+
+// WriteText writes the report as plain text, one section per list
+func WriteText(w io.Writer, report Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Team: %s\n", strings.Join(report.Members, ", "))
+	writeCoverage(&b, "Overall", report.Overall)
+
+	for _, list := range report.Lists {
+		fmt.Fprintf(&b, "\n== %s (%d problems) ==\n", list.Slug, len(list.Problems))
+		writeCoverage(&b, "Coverage", list.Coverage)
+
+		b.WriteString("Solved by:\n")
+		for _, p := range list.Problems {
+			solvers := list.SolvedBy[p.TitleSlug]
+			if len(solvers) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "  %-50s %s\n", p.Title, strings.Join(solvers, ", "))
+		}
+
+		fmt.Fprintf(&b, "Nobody solved (%d):\n", len(list.Unsolved))
+		for _, p := range list.Unsolved {
+			fmt.Fprintf(&b, "  %-50s %s\n", p.Title, p.Difficulty)
+		}
+
+		if len(list.Pairings) > 0 {
+			b.WriteString("Suggested pairings:\n")
+			for _, pairing := range list.Pairings {
+				fmt.Fprintf(&b, "  %-50s %s -> %s\n", pairing.Problem.Title, strings.Join(pairing.Solvers, ", "), strings.Join(pairing.Learners, ", "))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCoverage(b *strings.Builder, label string, coverages []Coverage) {
+	fmt.Fprintf(b, "%s:\n", label)
+	for _, c := range coverages {
+		fmt.Fprintf(b, "  %-20s %4d/%-4d %5.1f%%\n", c.Member, c.Solved, c.Total, c.Percent)
+	}
+}
@@ -0,0 +1,65 @@
+package team
+
+import (
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func member(t *testing.T, name string, slugs ...string) Member {
+	t.Helper()
+	solved, err := progress.Load(filepath.Join(t.TempDir(), "solved.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, slug := range slugs {
+		solved.Add(slug)
+	}
+	return Member{Name: name, Solved: solved}
+}
+
+func TestAggregate(t *testing.T) {
+	members := []Member{
+		member(t, "alice", "two-sum", "lru-cache", "word-ladder"),
+		member(t, "bob", "two-sum"),
+		member(t, "carol"),
+	}
+	list := List{Slug: "google-thirty-days", Problems: []scrapper.Problem{
+		{TitleSlug: "two-sum", Frequency: 90},
+		{TitleSlug: "lru-cache", Frequency: 50},
+		{TitleSlug: "word-ladder", Frequency: 70},
+		{TitleSlug: "trapping-rain-water"},
+	}}
+	other := List{Slug: "amazon-thirty-days", Problems: []scrapper.Problem{
+		{TitleSlug: "two-sum"},
+		{TitleSlug: "number-of-islands"},
+	}}
+
+	report := Aggregate(members, []List{list, other}, 2)
+	google := report.Lists[0]
+
+	if got := google.SolvedBy["two-sum"]; !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("two-sum solved by %v", got)
+	}
+	if c := google.Coverage[0]; c.Solved != 3 || c.Total != 4 || c.Percent != 75 {
+		t.Errorf("alice coverage %+v", c)
+	}
+	if len(google.Unsolved) != 1 || google.Unsolved[0].TitleSlug != "trapping-rain-water" {
+		t.Errorf("unsolved %v", google.Unsolved)
+	}
+
+	// problems solved by alice alone teach two members, the more frequent one first
+	var pairings []string
+	for _, p := range google.Pairings {
+		pairings = append(pairings, p.Problem.TitleSlug)
+	}
+	if !reflect.DeepEqual(pairings, []string{"word-ladder", "lru-cache"}) {
+		t.Errorf("pairings %v", pairings)
+	}
+
+	if c := report.Overall[1]; c.Solved != 1 || c.Total != 5 {
+		t.Errorf("overall coverage of bob must count distinct problems, got %+v", c)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"leetcode-scrapper/config"
+	"leetcode-scrapper/progress"
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/team"
+	"log"
+	"os"
+	"strings"
+)
+
+// teamMembers loads the progress of every member in spec, a comma separated list of
+// profile names or name=solved-file pairs; an empty spec means every configured profile
+func teamMembers(app *config.Application, spec string) ([]team.Member, error) {
+	entries := scrapper.SplitList(spec)
+	if len(entries) == 0 {
+		entries = app.ProfileNames()
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no team members, configure profiles or pass --members")
+	}
+
+	var members []team.Member
+	files := make(map[string]string)
+	for _, entry := range entries {
+		name, path, explicit := strings.Cut(entry, "=")
+		if !explicit {
+			profile, err := app.WithProfile(name)
+			if err != nil {
+				return nil, err
+			}
+			name, path = profile.Profile(), profile.Storage.Solved
+		}
+		if other, ok := files[path]; ok {
+			log.Printf("%s and %s share the solved file %s", other, name, path)
+		}
+		files[path] = name
+
+		solved, err := progress.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load progress of %s: %w", name, err)
+		}
+		members = append(members, team.Member{Name: name, Solved: solved})
+	}
+	return members, nil
+}
+
+func runTeam(args []string) error {
+	fs := flag.NewFlagSet("team", flag.ExitOnError)
+	var rf repositoryFlags
+	rf.register(fs)
+	memberSpec := fs.String("members", "", "comma separated profiles or name=solved-file pairs, default every configured profile")
+	pairings := fs.Int("pairings", 5, "pairing problems suggested per list")
+	asJSON := fs.Bool("json", false, "write the report as JSON")
+	if err := rf.parse(fs, args); err != nil {
+		return err
+	}
+
+	members, err := teamMembers(rf.config, *memberSpec)
+	if err != nil {
+		return err
+	}
+	repo, err := rf.repository()
+	if err != nil {
+		return err
+	}
+	slugs, err := listSlugs(repo, fs.Args(), rf.config.Companies())
+	if err != nil {
+		return err
+	}
+
+	var lists []team.List
+	for _, slug := range slugs {
+		response, err := scrapper.FetchFavoriteList(repo, slug, rf.config.Scraper.PageSize)
+		if err != nil {
+			return err
+		}
+		lists = append(lists, team.List{Slug: slug, Problems: response.Problems(slug)})
+	}
+
+	report := team.Aggregate(members, lists, *pairings)
+	if *asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return team.WriteText(os.Stdout, report)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"leetcode-scrapper/config"
+	"leetcode-scrapper/scrapper"
+	"leetcode-scrapper/snapshot"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// profileUsernames returns the usernames of every configured profile
+func profileUsernames(app *config.Application) []string {
+	var usernames []string
+	for _, name := range app.ProfileNames() {
+		if username := app.Profiles[name].Username; username != "" {
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
+
+// usernamePattern matches LeetCode usernames; anything else could escape the
+// snapshot directory, as usernames name the snapshot files
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// checkUsernames rejects usernames that are not plain LeetCode usernames
+func checkUsernames(usernames []string) error {
+	for _, username := range usernames {
+		if !usernamePattern.MatchString(username) {
+			return fmt.Errorf("invalid username %q, expected letters, digits, '_' and '-'", username)
+		}
+	}
+	return nil
+}
+
+func runUser(args []string) error {
+	fs := flag.NewFlagSet("user", flag.ExitOnError)
+	sf := scraperFlags{public: true}
+	sf.register(fs)
+	recent := fs.Int("recent", 10, "number of recent accepted submissions shown")
+	noSnapshot := fs.Bool("no-snapshot", false, "do not snapshot the fetched profiles even when app.storage.snapshot_retention is set")
+	if err := sf.parse(fs, args); err != nil {
+		return err
+	}
+
+	usernames := fs.Args()
+	if len(usernames) == 0 {
+		usernames = profileUsernames(sf.config)
+	}
+	if len(usernames) == 0 {
+		return fmt.Errorf("no usernames given, pass them or set app.profiles.<name>.username")
+	}
+	if err := checkUsernames(usernames); err != nil {
+		return err
+	}
+
+	scraper, err := sf.scraper()
+	if err != nil {
+		return err
+	}
+	storage := sf.config.Storage
+	for _, username := range usernames {
+		response, err := scraper.GetUserProfile(username, *recent)
+		if err != nil {
+			return err
+		}
+
+		// Compare with the previous snapshot before taking a new one
+		name := filepath.Join("users", username)
+		previous := previousProfile(storage.SnapshotDir, name)
+		printProfile(response, previous)
+
+		if !*noSnapshot && storage.SnapshotRetention > 0 {
+			if _, err := snapshot.Save(storage.SnapshotDir, name, response, storage.SnapshotRetention); err != nil {
+				return fmt.Errorf("failed to snapshot profile of %s: %w", username, err)
+			}
+		}
+	}
+	return nil
+}
+
+type profileSnapshot struct {
+	scrapper.UserProfileResponse
+	taken time.Time
+}
+
+// previousProfile returns the latest snapshot of a profile, nil when there is none
+func previousProfile(dir, name string) *profileSnapshot {
+	snapshots, err := snapshot.List(dir, name)
+	if err != nil || len(snapshots) == 0 {
+		return nil
+	}
+	latest := snapshots[len(snapshots)-1]
+	previous := &profileSnapshot{taken: latest.Taken}
+	if err := snapshot.Load(latest, &previous.UserProfileResponse); err != nil || previous.Data.MatchedUser == nil {
+		return nil
+	}
+	return previous
+}
+
+func printProfile(response *scrapper.UserProfileResponse, previous *profileSnapshot) {
+	user := response.Data.MatchedUser
+	fmt.Printf("%s", user.Username)
+	if user.Profile.RealName != "" {
+		fmt.Printf(" (%s)", user.Profile.RealName)
+	}
+	fmt.Printf(", ranking %d\n", user.Profile.Ranking)
+
+	fmt.Printf("  solved %d: easy %d, medium %d, hard %d", user.Solved("All"), user.Solved("Easy"), user.Solved("Medium"), user.Solved("Hard"))
+	if previous != nil {
+		fmt.Printf(" (%+d since %s)", user.Solved("All")-previous.Data.MatchedUser.Solved("All"), previous.taken.Format(time.DateOnly))
+	}
+	fmt.Println()
+
+	languages := make([]string, 0, len(user.LanguageProblemCount))
+	for _, l := range user.LanguageProblemCount {
+		languages = append(languages, fmt.Sprintf("%s %d", l.LanguageName, l.ProblemsSolved))
+	}
+	fmt.Printf("  languages: %s\n", strings.Join(languages, ", "))
+
+	tags := append(append(append([]scrapper.TagCount(nil), user.TagProblemCounts.Advanced...), user.TagProblemCounts.Intermediate...), user.TagProblemCounts.Fundamental...)
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].ProblemsSolved > tags[j].ProblemsSolved
+	})
+	var topics []string
+	for _, tag := range tags[:min(len(tags), 8)] {
+		topics = append(topics, fmt.Sprintf("%s %d", tag.TagName, tag.ProblemsSolved))
+	}
+	fmt.Printf("  top topics: %s\n", strings.Join(topics, ", "))
+
+	badges := make([]string, 0, len(user.Badges))
+	for _, badge := range user.Badges {
+		badges = append(badges, badge.DisplayName)
+	}
+	fmt.Printf("  badges: %d %s\n", len(badges), strings.Join(badges, ", "))
+
+	fmt.Println("  recently accepted:")
+	for _, submission := range response.Data.RecentAcSubmissionList {
+		fmt.Printf("    %s  %s\n", submission.Time().Format("2006-01-02 15:04"), submission.Title)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+func runWhoami(args []string) error {
+	fs := flag.NewFlagSet("whoami", flag.ExitOnError)
+	var sf scraperFlags
+	sf.register(fs)
+	if err := sf.parse(fs, args); err != nil {
+		return err
+	}
+
+	creds, err := sf.credentials()
+	if err != nil {
+		return err
+	}
+	if creds.Cookie == "" {
+		return fmt.Errorf("no cookie configured")
+	}
+	session := creds.Session()
+
+	fmt.Printf("Cookie:       %s\n", creds.Source)
+	fmt.Printf("Session:      %s\n", present(session.LeetCodeSession != ""))
+	fmt.Printf("CSRF token:   %s\n", present(session.CSRFToken != ""))
+	fmt.Printf("Clearance:    %s\n", present(session.CFClearance != ""))
+	if expiry, err := session.Expiry(); err == nil {
+		fmt.Printf("Expires:      %s (%s)\n", expiry.Format("2006-01-02 15:04"), relative(time.Until(expiry)))
+	} else {
+		fmt.Printf("Expires:      unknown, %v\n", err)
+	}
+
+	scraper, err := sf.scraper()
+	if err != nil {
+		return err
+	}
+	status, err := scraper.GetUserStatus()
+	if err != nil {
+		return fmt.Errorf("failed to validate the session: %w", err)
+	}
+	if !status.IsSignedIn {
+		return fmt.Errorf("LeetCode does not accept the session, copy a fresh cookie from your browser")
+	}
+
+	fmt.Printf("Signed in as: %s (id %d)\n", status.Username, status.UserID)
+	fmt.Printf("Premium:      %t\n", status.IsPremium)
+	return nil
+}
+
+func present(ok bool) string {
+	if ok {
+		return "present"
+	}
+	return "missing"
+}
+
+func relative(d time.Duration) string {
+	if d < 0 {
+		return "expired " + (-d).Round(time.Hour).String() + " ago"
+	}
+	days := int(d.Hours() / 24)
+	if days > 1 {
+		return fmt.Sprintf("in %d days", days)
+	}
+	return "in " + d.Round(time.Minute).String()
+}